@@ -31,8 +31,12 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/chrissimpkins/ink/inkio"
 	"github.com/chrissimpkins/ink/renderers"
@@ -47,6 +51,7 @@ const (
 	// Usage is the application usage string
 	Usage = `Usage: ink [options] [template path 1]...[template path n]
        ink [options] [template URL 1 ]...[template URL n ]
+       ink [options] [template directory 1]...[template directory n]
 `
 
 	// Help is the application help string
@@ -59,22 +64,127 @@ const (
 		"ink is a fast, flexible stream editor that supports local and remote source text file templating with built-in and user defined template tokens.\n\n" +
 		" Usage:\n" +
 		"  $ ink [options] [template path 1]...[template path n]\n" +
-		"  $ ink [options] [template URL 1 ]...[template URL n ]\n\n" +
+		"  $ ink [options] [template URL 1 ]...[template URL n ]\n" +
+		"  $ ink [options] [template directory 1]...[template directory n]\n\n" +
 		" Options:\n" +
-		"     --find=       String literal/regex pattern (re2) for user defined tokens\n" +
-		" -h, --help        Application help\n" +
-		"     --lint        Lint template against the ink template file specification\n" +
-		"     --replace=    Replacement string literal value for text substitutions\n" +
-		"     --stdout      Write rendered text to standard output stream\n" +
-		"     --trimnl      Trim newline value from replacement string\n" +
-		"     --usage       Application usage\n" +
-		" -v, --version     Application version\n\n" +
+		"     --data=            Template data file path or URL (JSON, YAML, TOML)\n" +
+		"     --delims=          Builtin template delimiters (default: {{,}})\n" +
+		"     --dotall           . matches newlines in --find and --sub regex patterns\n" +
+		"     --dry-run          Show diff of outfile changes without file writes\n" +
+		"     --env-prefix=      Environment variable prefix for the .Env template data\n" +
+		"     --exclude=         Glob pattern of files/directories to skip in template directories\n" +
+		"     --find=            String literal/regex pattern (re2) for user defined tokens\n" +
+		"     --follow-symlinks  Follow symbolic links in template directories\n" +
+		"     --format=          Report format: text (default), json, sarif (--lint only)\n" +
+		" -h, --help             Application help\n" +
+		"     --ignore-case      Case-insensitive --find and --sub matches\n" +
+		"     --in-place[=sfx]   Edit files in place with --find and --sub (optional backup suffix)\n" +
+		"     --include=         Glob pattern of templates to render in template directories\n" +
+		"     --lines=           Line numbers/ranges for --find and --sub replacements\n" +
+		"     --lint             Lint template against the ink template file specification\n" +
+		"     --mode=            Octal file mode for rendered files (e.g. 0755)\n" +
+		"     --multiline        ^ and $ match at line boundaries in --find and --sub regex\n" +
+		"     --no-preserve-mode Do not copy the template file mode to rendered files\n" +
+		"     --nth=             Replace the Nth --find and --sub match only\n" +
+		"     --outdir=          Output directory for rendered files (mirrors source tree)\n" +
+		"     --regex            Treat the --find value as a regex pattern without {{ }}\n" +
+		"     --region-end=      Regex of the marker line that ends a --region-start region\n" +
+		"     --region-start=    Regex of the marker line that starts a replacement region\n" +
+		"     --replace=         Replacement string literal value for text substitutions\n" +
+		"     --replace-mode=    --find/--sub replacements: expand (default), literal, template\n" +
+		"     --replaceN=        Replacement string for the {{.Two}}...{{.Ten}} tags (N = 2-10)\n" +
+		"     --report           Report find/replace match counts and line numbers per template\n" +
+		"     --report-preview   Report find/replace matches with a preview of the changed lines\n" +
+		"     --stdout           Write rendered text to standard output stream\n" +
+		"     --strict           Fail render on missing data keys, unresolved tokens, --find misses\n" +
+		"     --strict-env       Fail render on undefined env template function variables\n" +
+		"     --sub=             find=>replace[=>flags] substitution rule (repeatable)\n" +
+		"     --sub-file=        Substitution rules file path or URL (one rule per line)\n" +
+		"     --trimnl           Trim newline value from replacement string\n" +
+		"     --usage            Application usage\n" +
+		"     --watch            Re-render local templates on template and data changes\n" +
+		" -v, --version          Application version\n\n" +
 		"Full documentation and template specifications are available at https://github.com/chrissimpkins/ink\n"
 )
 
 var versionShort, versionLong, helpShort, helpLong, usageLong *bool
-var lintFlag, stdOutFlag, trimNLFlag *bool
-var findString, replaceString *string
+var lintFlag, stdOutFlag, trimNLFlag, strictFlag, strictEnvFlag, followSymlinksFlag *bool
+var includeGlobs, excludeGlobs, subDefinitions stringListFlag
+var inPlace inPlaceFlag
+var subFilePath, replaceModeString *string
+var subRules []renderers.SubRule // parsed --sub-file and --sub substitution rules in application order
+var outDir, fileModeString *string
+var noPreserveModeFlag, dryRunFlag, watchFlag, reportFlag, reportPreviewFlag *bool
+var regexFlag, ignoreCaseFlag, multilineFlag, dotAllFlag *bool
+var linesString, regionStartString, regionEndString *string
+var nthMatch *int
+var stdoutMutex sync.Mutex                     // serializes multi-line writes to the standard output stream from render go routines
+var outFileMode os.FileMode                    // parsed --mode option value, zero when not defined
+var templateDirRoots = make(map[string]string) // template directory argument for templates found in template directories
+var findString, replaceString, dataPath, envPrefix, formatString, delimsString *string
+var numberedReplaceStrings [9]*string // --replace2 through --replace10 definitions
+
+const (
+	watchInterval = 250 * time.Millisecond // file change polling interval in --watch mode
+	watchDebounce = 300 * time.Millisecond // quiet period after the last file change before --watch mode renders
+)
+
+// renderStatus is the outcome of a template render
+type renderStatus int
+
+const (
+	statusRendered  renderStatus = iota // rendered text was written to the outfile or standard output stream
+	statusFailed                        // template render or write failed
+	statusChanged                       // --dry-run: rendered text differs from the existing outfile
+	statusUnchanged                     // rendered text is identical to the existing outfile, the outfile is not written
+)
+
+// String returns the renderStatus name that is used in --format=json records
+func (status renderStatus) String() string {
+	switch status {
+	case statusRendered:
+		return "rendered"
+	case statusChanged:
+		return "changed"
+	case statusUnchanged:
+		return "unchanged"
+	}
+	return "failed"
+}
+
+// stringListFlag is a command line flag that can be defined multiple times and maintains all definitions in order
+type stringListFlag []string
+
+func (s *stringListFlag) String() string { return strings.Join(*s, ",") }
+
+func (s *stringListFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+// inPlaceFlag is the --in-place[=suffix] command line flag.  The flag is defined without a value to edit files in place
+// and with a backup file name suffix value to also keep a copy of the original files (e.g. --in-place=.bak)
+type inPlaceFlag struct {
+	enabled bool   // edit files in place
+	suffix  string // backup file name suffix, no backup when empty
+}
+
+func (f *inPlaceFlag) String() string { return f.suffix }
+
+func (f *inPlaceFlag) Set(value string) error {
+	switch value {
+	case "true":
+		f.enabled, f.suffix = true, ""
+	case "false":
+		f.enabled, f.suffix = false, ""
+	default:
+		f.enabled, f.suffix = true, value
+	}
+	return nil
+}
+
+// IsBoolFlag permits the --in-place flag definition without a value
+func (f *inPlaceFlag) IsBoolFlag() bool { return true }
 
 func init() {
 	// define available command line flag arguments
@@ -84,11 +194,42 @@ func init() {
 	helpLong = flag.Bool("help", false, "Help")
 	usageLong = flag.Bool("usage", false, "Usage")
 
+	dataPath = flag.String("data", "", "Template data file path or URL")
+	delimsString = flag.String("delims", "", "Comma separated left and right builtin template delimiters")
+	dryRunFlag = flag.Bool("dry-run", false, "Show a diff of outfile changes without writing files")
+	envPrefix = flag.String("env-prefix", "", "Environment variable prefix for .Env template data")
+	flag.Var(&excludeGlobs, "exclude", "Glob pattern for files and directories to skip in template directories (repeatable)")
 	findString = flag.String("find", "", "Optional find string for replacement")
+	regexFlag = flag.Bool("regex", false, "Treat the --find value as a regular expression pattern without {{ }} delimiters")
+	ignoreCaseFlag = flag.Bool("ignore-case", false, "Case-insensitive --find and --sub matches")
+	multilineFlag = flag.Bool("multiline", false, "^ and $ match at line boundaries in --find and --sub regular expressions")
+	dotAllFlag = flag.Bool("dotall", false, ". matches newlines in --find and --sub regular expressions")
+	linesString = flag.String("lines", "", "Line numbers and line number ranges for --find and --sub replacements")
+	regionStartString = flag.String("region-start", "", "Regular expression of the marker line that starts a replacement region")
+	regionEndString = flag.String("region-end", "", "Regular expression of the marker line that ends a replacement region")
+	nthMatch = flag.Int("nth", 0, "Replace the Nth --find and --sub match only")
+	formatString = flag.String("format", "text", "Lint and render report format (text, json, sarif)")
+	fileModeString = flag.String("mode", "", "Octal file mode for rendered files")
+	noPreserveModeFlag = flag.Bool("no-preserve-mode", false, "Do not copy the template file mode to rendered files")
+	outDir = flag.String("outdir", "", "Output directory for rendered files")
+	followSymlinksFlag = flag.Bool("follow-symlinks", false, "Follow symbolic links in template directories")
+	flag.Var(&inPlace, "in-place", "Edit files in place with user-defined template substitutions, optional backup file suffix value")
+	flag.Var(&includeGlobs, "include", "Glob pattern for templates to render in template directories (repeatable)")
 	replaceString = flag.String("replace", "", "Replacement string")
+	replaceModeString = flag.String("replace-mode", renderers.ReplaceExpand, "Replacement mode of user-defined template substitutions (expand, literal, template)")
+	for i := range numberedReplaceStrings {
+		numberedReplaceStrings[i] = flag.String(fmt.Sprintf("replace%d", i+2), "", fmt.Sprintf("Replacement string for template tag number %d", i+2))
+	}
+	reportFlag = flag.Bool("report", false, "Report the find and replace matches in each template")
+	reportPreviewFlag = flag.Bool("report-preview", false, "Report the find and replace matches in each template with a preview of the changed lines")
 	lintFlag = flag.Bool("lint", false, "Lint the template file(s)")
+	flag.Var(&subDefinitions, "sub", "find=>replace[=>flags] substitution rule for user-defined templates (may be used more than once)")
+	subFilePath = flag.String("sub-file", "", "Substitution rules file path or URL")
 	stdOutFlag = flag.Bool("stdout", false, "Write to standard output stream")
 	trimNLFlag = flag.Bool("trimnl", false, "trim newline characters at the end of the replacement string")
+	strictFlag = flag.Bool("strict", false, "Fail render on missing template data keys, unresolved tokens, and unmatched find strings")
+	strictEnvFlag = flag.Bool("strict-env", false, "Fail render on undefined environment variables")
+	watchFlag = flag.Bool("watch", false, "Re-render local templates when templates, included files, or data files change")
 }
 
 func main() {
@@ -117,16 +258,50 @@ func main() {
 	}
 
 	// parse all non-flag arguments on the command line to string slice data elements
-	templatePaths := flag.Args()
+	var templatePaths []string
 	var localTemplatePaths []string
 	var remoteTemplatePaths []string
+	commandlinefail := false
 
-	// parse by local and remote template paths
-	for _, templatePath := range templatePaths {
-		if strings.HasPrefix(templatePath, "http://") || strings.HasPrefix(templatePath, "https://") {
+	// parse by local and remote template paths, local template directories are expanded to the *.in template files
+	// that they contain, or to the --include files with any file extension in --in-place mode
+	for _, templatePath := range flag.Args() {
+		if inkio.IsURL(templatePath) {
 			remoteTemplatePaths = append(remoteTemplatePaths, templatePath)
+			templatePaths = append(templatePaths, templatePath)
+		} else if fileInfo, staterr := os.Stat(templatePath); staterr == nil && fileInfo.IsDir() && inPlace.enabled {
+			if len(includeGlobs) == 0 {
+				os.Stderr.WriteString("[ink] ERROR: Use the --include option to define the files that are edited in directory '" + templatePath + "' with the --in-place option.\n")
+				commandlinefail = true
+				continue
+			}
+			dirFilePaths, finderr := utilities.FindFiles(templatePath, includeGlobs, excludeGlobs, *followSymlinksFlag)
+			if finderr != nil {
+				os.Stderr.WriteString("[ink] ERROR: Unable to search directory '" + templatePath + "'. " + fmt.Sprintf("%v\n", finderr))
+				commandlinefail = true
+			} else if len(dirFilePaths) == 0 {
+				os.Stderr.WriteString("[ink] ERROR: Directory '" + templatePath + "' does not contain files that match the --include option.\n")
+				commandlinefail = true
+			}
+			localTemplatePaths = append(localTemplatePaths, dirFilePaths...)
+			templatePaths = append(templatePaths, dirFilePaths...)
+		} else if staterr == nil && fileInfo.IsDir() {
+			dirTemplatePaths, finderr := utilities.FindTemplates(templatePath, includeGlobs, excludeGlobs, *followSymlinksFlag)
+			if finderr != nil {
+				os.Stderr.WriteString("[ink] ERROR: Unable to search template directory '" + templatePath + "'. " + fmt.Sprintf("%v\n", finderr))
+				commandlinefail = true
+			} else if len(dirTemplatePaths) == 0 {
+				os.Stderr.WriteString("[ink] ERROR: Template directory '" + templatePath + "' does not contain *.in template files.\n")
+				commandlinefail = true
+			}
+			for _, dirTemplatePath := range dirTemplatePaths {
+				templateDirRoots[dirTemplatePath] = templatePath
+			}
+			localTemplatePaths = append(localTemplatePaths, dirTemplatePaths...)
+			templatePaths = append(templatePaths, dirTemplatePaths...)
 		} else {
 			localTemplatePaths = append(localTemplatePaths, templatePath)
+			templatePaths = append(templatePaths, templatePath)
 		}
 	}
 
@@ -135,13 +310,13 @@ func main() {
 		COMMAND LINE VALIDATIONS
 
 	*/
-	commandlinefail := false
 
 	// confirm that the proper file extension is included on all local AND remote templates
 	// NOTE: skip check if user requests print to stdout stream as we assume they are going to manage outfile write path
-	//       themselves or do not need an outfile path (e.g. viewing in terminal)
+	//       themselves or do not need an outfile path (e.g. viewing in terminal), and in --in-place mode where the
+	//       template file is the outfile
 	// this extension formatting is used to construct the outfile path and should not be changed
-	if !*stdOutFlag {
+	if !*stdOutFlag && !inPlace.enabled {
 		for _, templatePath := range templatePaths {
 			if !validators.HasCorrectExtension(templatePath) {
 				os.Stderr.WriteString("[ink] ERROR: Argument '" + templatePath + "' is not a properly specified template with *.in file extension.\n")
@@ -161,6 +336,161 @@ func main() {
 		}
 
 	}
+	// confirm that the --mode option defines octal file permission bits
+	if len(*fileModeString) > 0 {
+		mode, modeerr := strconv.ParseUint(*fileModeString, 8, 32)
+		if modeerr != nil || mode == 0 || mode > 0777 {
+			os.Stderr.WriteString("[ink] ERROR: The --mode option value '" + *fileModeString + "' is not a valid octal file mode (e.g. 0755).\n")
+			commandlinefail = true
+		}
+		outFileMode = os.FileMode(mode)
+	}
+	// confirm that the --delims option defines a left and right delimiter pair
+	if len(*delimsString) > 0 {
+		leftDelim, rightDelim, delimserr := renderers.ParseDelims(*delimsString)
+		if delimserr != nil {
+			os.Stderr.WriteString("[ink] ERROR: Invalid --delims option value. " + fmt.Sprintf("%v\n", delimserr))
+			commandlinefail = true
+		} else {
+			renderers.LeftDelim, renderers.RightDelim = leftDelim, rightDelim
+		}
+	}
+	// confirm that the --format option defines a supported report format
+	switch *formatString {
+	case "text", "json":
+	case "sarif":
+		if !*lintFlag {
+			os.Stderr.WriteString("[ink] ERROR: The --format=sarif option is only supported with the --lint option.\n")
+			commandlinefail = true
+		}
+	default:
+		os.Stderr.WriteString("[ink] ERROR: The --format option value '" + *formatString + "' is not supported. Use text, json, or sarif.\n")
+		commandlinefail = true
+	}
+	if *formatString == "json" && *stdOutFlag && !*lintFlag {
+		os.Stderr.WriteString("[ink] ERROR: The --format=json option cannot be used with the --stdout option.\n")
+		commandlinefail = true
+	}
+	// confirm that the --sub-file and --sub options define valid substitution rules
+	if len(*subFilePath) > 0 {
+		fileRules, ruleserr := renderers.ReadSubRulesFile(*subFilePath)
+		if ruleserr != nil {
+			os.Stderr.WriteString("[ink] ERROR: Invalid --sub-file option file '" + *subFilePath + "'. " + fmt.Sprintf("%v\n", ruleserr))
+			commandlinefail = true
+		}
+		subRules = append(subRules, fileRules...)
+	}
+	for _, subDefinition := range subDefinitions {
+		rule, ruleerr := renderers.ParseSubRule(subDefinition)
+		if ruleerr != nil {
+			os.Stderr.WriteString("[ink] ERROR: Invalid --sub option value. " + fmt.Sprintf("%v\n", ruleerr))
+			commandlinefail = true
+			continue
+		}
+		subRules = append(subRules, rule)
+	}
+	// confirm that the --replace-mode option defines a supported replacement mode and apply it to the rules that do not
+	// define a replacement mode flag
+	if modeerr := (renderers.SubRule{Find: "ink", Mode: *replaceModeString}).Validate(); modeerr != nil {
+		os.Stderr.WriteString("[ink] ERROR: Invalid --replace-mode option value. " + fmt.Sprintf("%v\n", modeerr))
+		commandlinefail = true
+	} else {
+		for i := range subRules {
+			// the --ignore-case, --multiline, and --dotall options apply to all rules
+			subRules[i].IgnoreCase = subRules[i].IgnoreCase || *ignoreCaseFlag
+			subRules[i].Multiline = subRules[i].Multiline || *multilineFlag
+			subRules[i].DotAll = subRules[i].DotAll || *dotAllFlag
+			if subRules[i].Nth == 0 {
+				subRules[i].Nth = *nthMatch
+			}
+			if len(subRules[i].Mode) == 0 {
+				subRules[i].Mode = *replaceModeString
+			}
+			if ruleerr := subRules[i].Validate(); ruleerr != nil {
+				os.Stderr.WriteString("[ink] ERROR: Invalid substitution rule '" + subRules[i].String() + "'. " + fmt.Sprintf("%v\n", ruleerr))
+				commandlinefail = true
+			}
+		}
+	}
+	if (*regexFlag || *ignoreCaseFlag || *multilineFlag || *dotAllFlag) && !userTemplateMode() {
+		os.Stderr.WriteString("[ink] ERROR: The --regex, --ignore-case, --multiline, and --dotall options require a --find= or --sub render.\n")
+		commandlinefail = true
+	}
+	// confirm that the --lines, --region-start, --region-end, and --nth options define valid replacement scopes
+	if (len(*linesString) > 0 || len(*regionStartString) > 0 || len(*regionEndString) > 0 || *nthMatch != 0) && !userTemplateMode() {
+		os.Stderr.WriteString("[ink] ERROR: The --lines, --region-start, --region-end, and --nth options require a --find= or --sub render.\n")
+		commandlinefail = true
+	}
+	if len(*linesString) > 0 {
+		lineRanges, rangeserr := renderers.ParseLineRanges(*linesString)
+		if rangeserr != nil {
+			os.Stderr.WriteString("[ink] ERROR: Invalid --lines option value. " + fmt.Sprintf("%v\n", rangeserr))
+			commandlinefail = true
+		}
+		renderers.LineRanges = lineRanges
+	}
+	if len(*regionStartString) > 0 != (len(*regionEndString) > 0) {
+		os.Stderr.WriteString("[ink] ERROR: The --region-start and --region-end options must be used together.\n")
+		commandlinefail = true
+	} else if len(*regionStartString) > 0 {
+		regionStart, starterr := regexp.Compile(*regionStartString)
+		if starterr != nil {
+			os.Stderr.WriteString("[ink] ERROR: Invalid --region-start option regular expression. " + fmt.Sprintf("%v\n", starterr))
+			commandlinefail = true
+		}
+		regionEnd, enderr := regexp.Compile(*regionEndString)
+		if enderr != nil {
+			os.Stderr.WriteString("[ink] ERROR: Invalid --region-end option regular expression. " + fmt.Sprintf("%v\n", enderr))
+			commandlinefail = true
+		}
+		renderers.RegionStart, renderers.RegionEnd = regionStart, regionEnd
+	}
+	if *nthMatch < 0 {
+		os.Stderr.WriteString("[ink] ERROR: The --nth option value must be 1 or greater.\n")
+		commandlinefail = true
+	}
+	if len(*findString) > 0 {
+		if finderr := findRule(replaceString).Validate(); finderr != nil && !*lintFlag { // lints report invalid --find= values
+			os.Stderr.WriteString("[ink] ERROR: Invalid --find option value. " + fmt.Sprintf("%v\n", finderr))
+			commandlinefail = true
+		}
+	}
+	if len(subRules) > 0 && *lintFlag {
+		os.Stderr.WriteString("[ink] ERROR: The --sub and --sub-file options are not supported with the --lint option.\n")
+		commandlinefail = true
+	}
+	// confirm that the --report options are used with user-defined template renders
+	if *reportPreviewFlag {
+		*reportFlag = true
+	}
+	if *reportFlag && (!userTemplateMode() || *lintFlag) {
+		os.Stderr.WriteString("[ink] ERROR: The --report and --report-preview options require a --find= or --sub render without the --lint option.\n")
+		commandlinefail = true
+	}
+	// confirm that --in-place mode edits local files with user template substitutions
+	if inPlace.enabled {
+		if !userTemplateMode() || *lintFlag {
+			os.Stderr.WriteString("[ink] ERROR: The --in-place option requires a --find= or --sub render without the --lint option.\n")
+			commandlinefail = true
+		}
+		if *stdOutFlag || len(*outDir) > 0 || *watchFlag {
+			os.Stderr.WriteString("[ink] ERROR: The --in-place option cannot be used with the --stdout, --outdir, or --watch options.\n")
+			commandlinefail = true
+		}
+		if len(remoteTemplatePaths) > 0 {
+			os.Stderr.WriteString("[ink] ERROR: The --in-place option requires local file paths.\n")
+			commandlinefail = true
+		}
+		if strings.ContainsAny(inPlace.suffix, `/\`) {
+			os.Stderr.WriteString("[ink] ERROR: The --in-place backup suffix '" + inPlace.suffix + "' must not include path separators.\n")
+			commandlinefail = true
+		}
+	}
+	// confirm that --watch mode has local template files to watch and writes the rendered files
+	if watchMessage := watchOptionsMessage(localTemplatePaths); len(watchMessage) > 0 {
+		os.Stderr.WriteString("[ink] ERROR: " + watchMessage + "\n")
+		commandlinefail = true
+	}
 	// exit with status code 1 if any of the above command line validations failed
 	if commandlinefail {
 		os.Exit(1)
@@ -172,17 +502,63 @@ func main() {
 
 	*/
 	if *lintFlag {
-		failFound := false // flag that tracks presence of linting failure(s), used for exit status code
+		failFound := false             // flag that tracks presence of linting failure(s), used for exit status code
+		var records []utilities.Record // --format=json and --format=sarif lint results
 		for _, templatePath := range templatePaths {
 			// Create a new template and parse the letter into it.
-			success, err := validators.LintTemplateSuccess(templatePath)
-			if success {
-				fmt.Println("[✓] " + templatePath + ": Valid template")
+			start := time.Now()
+			var diagnostics []validators.Diagnostic
+			var err error
+			matches := -1 // --find= value matches, user-defined templates only
+			if len(*findString) > 0 {
+				diagnostics, matches, err = validators.LintUserTemplateRule(templatePath, findRule(replaceString))
 			} else {
+				diagnostics, err = validators.LintTemplate(templatePath)
+			}
+			success := err == nil && !validators.HasErrors(diagnostics)
+			if !success {
+				failFound = true
+			}
+			if *formatString != "text" {
+				status := "valid"
+				if !success {
+					status = "invalid"
+				}
+				record := utilities.NewRecord(templatePath, status, "", time.Since(start), err)
+				record.Diagnostics = diagnostics
+				if matches >= 0 {
+					record.Matches = &matches
+				}
+				records = append(records, record)
+				continue
+			}
+			if success && matches >= 0 {
+				fmt.Printf("[✓] %s: Valid template (%d --find matches)\n", templatePath, matches)
+			} else if success {
+				fmt.Println("[✓] " + templatePath + ": Valid template")
+			} else if err != nil {
 				errstring := fmt.Sprintf("%v", err)
 				os.Stderr.WriteString("[X] " + templatePath + ": FAIL --- " + errstring + "\n")
-				failFound = true
+			} else {
+				os.Stderr.WriteString("[X] " + templatePath + ": FAIL\n")
 			}
+			// diagnostics are reported in `path:line:column: severity: message [rule]` format
+			for _, diagnostic := range diagnostics {
+				os.Stderr.WriteString("    " + templatePath + ":" + diagnostic.String() + "\n")
+			}
+		}
+		switch *formatString {
+		case "json":
+			for _, record := range records {
+				utilities.WriteJSONRecord(os.Stdout, record)
+			}
+		case "sarif":
+			sarif, sariferr := utilities.SARIFLog(records, Version)
+			if sariferr != nil {
+				os.Stderr.WriteString("[ink] ERROR: Unable to create the SARIF lint report. " + fmt.Sprintf("%v\n", sariferr))
+				os.Exit(1)
+			}
+			os.Stdout.WriteString(string(sarif) + "\n")
 		}
 		// if found a linting failure for any requested template file, exit with status code 1
 		if failFound {
@@ -192,12 +568,35 @@ func main() {
 		}
 	}
 
+	/*
+
+		LOAD THE TEMPLATE DATA FILE & ENVIRONMENT SETTINGS
+
+	*/
+	if dataerr := loadTemplateData(); dataerr != nil {
+		os.Stderr.WriteString("[ink] ERROR: Unable to read template data file '" + *dataPath + "'. " + fmt.Sprintf("%v\n", dataerr))
+		os.Exit(1)
+	}
+	renderers.EnvPrefix = *envPrefix
+	renderers.StrictEnv = *strictEnvFlag
+	renderers.Strict = *strictFlag
+
 	/*
 
 	   PREPARE THE REPLACEMENT STRING FOR WRITE
 
 	*/
 
+	// map user defined --replace2 through --replace10 values to the {{.Two}}...{{.Ten}} builtin template tags
+	numberedFieldNames := []string{"Two", "Three", "Four", "Five", "Six", "Seven", "Eight", "Nine", "Ten"}
+	flag.Visit(func(f *flag.Flag) {
+		for i, numberedReplaceString := range numberedReplaceStrings {
+			if f.Name == fmt.Sprintf("replace%d", i+2) {
+				renderers.NumberedReplaceStrings[numberedFieldNames[i]] = *numberedReplaceString
+			}
+		}
+	})
+
 	if len(*replaceString) > 0 {
 		// do nothing, gtg if defined
 	} else if validators.StdinValidates(os.Stdin) {
@@ -211,9 +610,9 @@ func main() {
 
 		*replaceString = stdinReplaceBytes.String()
 
-	} else {
-		// user did not specify a replacement string with the --replace flag on the command line
-		// or pipe replacement string to stdin stream
+	} else if len(renderers.NumberedReplaceStrings) == 0 && renderers.TemplateData == nil && len(renderers.EnvPrefix) == 0 && (len(*findString) > 0 || len(subRules) == 0) {
+		// user did not specify a replacement string with the --replace flag on the command line,
+		// pipe replacement string to stdin stream, or define other template data for the render
 		os.Stderr.WriteString("[ink] ERROR: Missing replacement string for template render.\n")
 		os.Stderr.WriteString(Usage)
 		os.Exit(1)
@@ -221,7 +620,7 @@ func main() {
 
 	// Trim newlines if requested on commandline with --trimnl flag
 	if *trimNLFlag {
-		*replaceString = strings.TrimRight(*replaceString, "\n")
+		trimReplaceStrings(replaceString)
 	}
 
 	/*
@@ -233,21 +632,16 @@ func main() {
 
 	var wg sync.WaitGroup
 
-	errorc := make(chan bool) // channel used to communicate render/write failures from go routines that are executing them
+	statusc := make(chan renderStatus) // channel used to communicate render/write outcomes from go routines that are executing them
 	// Iterate through local templates and render them in parallel
 	for _, templatePath := range localTemplatePaths {
 		wg.Add(1)
 		go func(templatePath string, replaceString *string, stdOutFlag *bool) {
 			defer wg.Done()
-			err := renderLocal(templatePath, replaceString, stdOutFlag)
-			if err != nil {
-				os.Stderr.WriteString(fmt.Sprintf("[ink] ERROR: Failed to render template %s. %v\n", templatePath, err))
-				errorc <- true // true = error occurred
-			}
-			errorc <- false                 // false = error did not occur
-			if !*stdOutFlag && err == nil { // print confirmation only if the user did not print to render to stdout stream
-				fmt.Printf("[ink] Template %s rendered successfully.\n", templatePath)
-			}
+			start := time.Now()
+			status, outPath, matchReport, err := renderLocal(templatePath, replaceString, stdOutFlag)
+			reportRender(templatePath, status, outPath, matchReport, err, time.Since(start))
+			statusc <- status
 		}(templatePath, replaceString, stdOutFlag)
 	}
 
@@ -256,99 +650,450 @@ func main() {
 		wg.Add(1)
 		go func(templateURL string, replaceString *string, stdOutFlag *bool) {
 			defer wg.Done()
-			err := renderRemote(templateURL, replaceString, stdOutFlag)
-			if err != nil {
-				os.Stderr.WriteString(fmt.Sprintf("[ink] ERROR: Failed to render remote template %s. %v\n", templateURL, err))
-				errorc <- true // true = error occurred
-			}
-			errorc <- false
-			if !*stdOutFlag && err == nil {
-				fmt.Printf("[ink] Template %s rendered successfully.\n", templateURL)
-			}
+			start := time.Now()
+			status, outPath, matchReport, err := renderRemote(templateURL, replaceString, stdOutFlag)
+			reportRender(templateURL, status, outPath, matchReport, err, time.Since(start))
+			statusc <- status
 		}(templateURL, replaceString, stdOutFlag)
 	}
 
 	// must make the wait and close concurrent with the executing worker go routines
 	go func() {
 		wg.Wait()
-		close(errorc)
+		close(statusc)
 	}()
 
-	exitFail := false                   // flag to indicate that a failure occurred for appropriate exit status code on application exit
-	for errorOccurred := range errorc { // iterate through the booleans to determine if error occurred during execution
-		if errorOccurred == true {
+	exitFail := false    // flag to indicate that a failure occurred for appropriate exit status code on application exit
+	changeFound := false // flag to indicate that a --dry-run render differs from an existing outfile
+	for status := range statusc {
+		switch status {
+		case statusFailed:
 			exitFail = true
+		case statusChanged:
+			changeFound = true
 		}
 	}
 
+	// --watch mode re-renders local templates as they change and does not return
+	if *watchFlag {
+		watchTemplates(localTemplatePaths, replaceString, stdOutFlag)
+	}
+
 	if exitFail {
 		os.Exit(1) // fail with exit status code 1 if error occurred during execution of any template renders
 	}
 
 	// reachable only if error did not occur
+	// --dry-run renders exit with status code 1 when any outfile would change
+	if *dryRunFlag {
+		if changeFound {
+			if *formatString == "text" {
+				os.Stderr.WriteString("[ink] Dry run complete. One or more outfiles are not up to date.\n")
+			}
+			os.Exit(1)
+		}
+		if *formatString == "text" {
+			os.Stdout.WriteString("[ink] Dry run complete. All outfiles are up to date.\n")
+		}
+		return
+	}
+
 	// indicate render completed successfully if not printing to stdout stream
 	// this is intended for user notification in the setting of "long" running multi-template renders
-	if !*stdOutFlag { // confirm that the writes are all complete if user did not render to stdout stream
+	if !*stdOutFlag && *formatString == "text" { // confirm that the writes are all complete if user did not render to stdout stream
 		os.Stdout.WriteString("[ink] Render complete.\n")
 	}
 }
 
-// renderLocal handles local template file rendering, called in parallel fashion from main function
-func renderLocal(templatePath string, replaceString *string, stdOutFlag *bool) error {
-	// if user specified --find flag with appropriate argument, perform user template rendering
-	if len(*findString) > 0 {
-		renderedStringPointer, rendererr := renderers.RenderFromLocalUserTemplate(templatePath, findString, replaceString)
-		if rendererr != nil {
-			return rendererr
+// userTemplateMode returns true when the templates are rendered as user-defined templates with the --find= option or
+// the --sub and --sub-file substitution rules
+func userTemplateMode() bool {
+	return len(*findString) > 0 || len(subRules) > 0
+}
+
+// watchOptionsMessage returns the command line error message when the --watch option is used without the local
+// template paths localTemplatePaths or with the --dry-run option, which would report the same outfile diffs on every
+// change without writing files.  Returns an empty string for valid options
+func watchOptionsMessage(localTemplatePaths []string) string {
+	switch {
+	case !*watchFlag:
+		return ""
+	case len(localTemplatePaths) == 0:
+		return "The --watch option requires one or more local template paths."
+	case *dryRunFlag:
+		return "The --watch option cannot be used with the --dry-run option."
+	}
+	return ""
+}
+
+// trimReplaceStrings trims the newline characters at the end of the replacement string replaceString and of the
+// --replace2 ... --replace10 numbered replacement strings
+func trimReplaceStrings(replaceString *string) {
+	*replaceString = strings.TrimRight(*replaceString, "\n")
+	for name, numberedReplaceString := range renderers.NumberedReplaceStrings {
+		renderers.NumberedReplaceStrings[name] = strings.TrimRight(numberedReplaceString, "\n")
+	}
+}
+
+// findRule returns the substitution rule of the --find= option with the replacement string replaceString and the
+// --replace-mode, --regex, --ignore-case, --multiline, --dotall, and --nth options
+func findRule(replaceString *string) renderers.SubRule {
+	return renderers.SubRule{
+		Find:       *findString,
+		Replace:    *replaceString,
+		Mode:       *replaceModeString,
+		Regex:      *regexFlag,
+		IgnoreCase: *ignoreCaseFlag,
+		Multiline:  *multilineFlag,
+		DotAll:     *dotAllFlag,
+		Nth:        *nthMatch,
+	}
+}
+
+// userRules returns the substitution rules of user-defined template renders with the replacement string
+// replaceString.  The --find= option rule is applied before the --sub-file rules and the --sub rules
+func userRules(replaceString *string) []renderers.SubRule {
+	if len(*findString) == 0 {
+		return subRules
+	}
+	return append([]renderers.SubRule{findRule(replaceString)}, subRules...)
+}
+
+// reportRender prints the render outcome for the local template path or remote template URL templatePath.  outPath is
+// the outfile path, empty for failed renders and renders to the standard output stream.  matchReport is the --report
+// match report of user template renders (nil = undefined).  Successful renders are not reported in text format when
+// the user renders to the standard output stream.  --format=json reports write one JSON record per template to the
+// standard output stream
+func reportRender(templatePath string, status renderStatus, outPath string, matchReport *renderers.MatchReport, err error, duration time.Duration) {
+	if *formatString == "json" {
+		record := utilities.NewRecord(templatePath, status.String(), outPath, duration, err)
+		if matchReport != nil {
+			record.Matches = &matchReport.Matches
+			record.Lines = matchReport.Lines
+			if *reportPreviewFlag {
+				record.Changes = matchReport.Changes
+			}
 		}
-		writeerr := inkio.WriteString(templatePath, *stdOutFlag, renderedStringPointer)
-		if writeerr != nil {
-			return writeerr
+		stdoutMutex.Lock()
+		utilities.WriteJSONRecord(os.Stdout, record)
+		stdoutMutex.Unlock()
+		return
+	}
+
+	switch status {
+	case statusFailed:
+		if inkio.IsURL(templatePath) {
+			os.Stderr.WriteString(fmt.Sprintf("[ink] ERROR: Failed to render remote template %s. %v\n", templatePath, err))
+		} else {
+			os.Stderr.WriteString(fmt.Sprintf("[ink] ERROR: Failed to render template %s. %v\n", templatePath, err))
 		}
-		return nil
+	case statusRendered:
+		if len(outPath) > 0 {
+			fmt.Printf("[ink] Template %s rendered successfully.\n", templatePath)
+		}
+	case statusChanged:
+		fmt.Printf("[ink] Template %s outfile is not up to date.\n", templatePath)
+	case statusUnchanged:
+		fmt.Printf("[ink] Template %s is unchanged.\n", templatePath)
+	}
+	if matchReport != nil && status != statusFailed {
+		reportMatches(templatePath, matchReport)
+	}
+}
+
+// reportMatches prints the --report match report matchReport for the template path or URL templatePath.  The report
+// is written to the standard error stream when the user renders to the standard output stream
+func reportMatches(templatePath string, matchReport *renderers.MatchReport) {
+	var report strings.Builder
+	lines := make([]string, len(matchReport.Lines))
+	for i, line := range matchReport.Lines {
+		lines[i] = strconv.Itoa(line)
+	}
+	switch {
+	case matchReport.Matches == 0:
+		report.WriteString(fmt.Sprintf("[ink] Template %s: 0 matches.\n", templatePath))
+	case len(lines) == 1:
+		report.WriteString(fmt.Sprintf("[ink] Template %s: %d matches on line %s.\n", templatePath, matchReport.Matches, lines[0]))
+	default:
+		report.WriteString(fmt.Sprintf("[ink] Template %s: %d matches on lines %s.\n", templatePath, matchReport.Matches, strings.Join(lines, ", ")))
+	}
+	if *reportPreviewFlag {
+		for _, change := range matchReport.Changes {
+			for i, line := range strings.Split(change.Before, "\n") {
+				report.WriteString(fmt.Sprintf("    %d: -%s\n", change.Line+i, line))
+			}
+			for i, line := range strings.Split(change.After, "\n") {
+				report.WriteString(fmt.Sprintf("    %d: +%s\n", change.Line+i, line))
+			}
+		}
+	}
+
+	stdoutMutex.Lock()
+	defer stdoutMutex.Unlock()
+	if *stdOutFlag {
+		os.Stderr.WriteString(report.String())
+	} else {
+		os.Stdout.WriteString(report.String())
+	}
+}
+
+// renderLocal handles local template file rendering, called in parallel fashion from main function.  Returns the
+// render status, the outfile path (empty for failed renders and renders to the standard output stream), the --report
+// match report (nil when undefined) and error
+func renderLocal(templatePath string, replaceString *string, stdOutFlag *bool) (renderStatus, string, *renderers.MatchReport, error) {
+	var renderedStringPointer *string
+	var frontMatter *renderers.FrontMatter // builtin template front matter, nil without a front matter block
+	var matchReport *renderers.MatchReport // user template --report match report, nil when undefined
+	var rendererr error
+	if userTemplateMode() {
+		// if user specified --find flag with appropriate argument or substitution rules, perform user template rendering
+		renderedStringPointer, matchReport, rendererr = renderers.RenderFromLocalUserTemplateWithRules(templatePath, userRules(replaceString), *reportFlag)
+	} else {
+		// otherwise perform builtin template rendering
+		renderedStringPointer, frontMatter, rendererr = renderers.RenderFromLocalInkTemplateWithFrontMatter(templatePath, replaceString)
 	}
-	// otherwise perform builtin template rendering
-	renderedStringPointer, rendererr := renderers.RenderFromLocalInkTemplate(templatePath, replaceString)
 	if rendererr != nil {
-		return rendererr
+		return statusFailed, "", nil, rendererr
 	}
-	writeerr := inkio.WriteString(templatePath, *stdOutFlag, renderedStringPointer)
-	if writeerr != nil {
-		return writeerr
+	status, outPath, writeerr := writeRendered(templatePath, *stdOutFlag, renderedStringPointer, frontMatter)
+	return status, outPath, matchReport, writeerr
+}
+
+// renderRemote handles remote template file rendering, called in parallel fashion from main function.  Returns the
+// render status, the outfile path (empty for failed renders and renders to the standard output stream), the --report
+// match report (nil when undefined) and error
+func renderRemote(templateURL string, replaceString *string, stdOutFlag *bool) (renderStatus, string, *renderers.MatchReport, error) {
+	var renderedStringPointer *string
+	var frontMatter *renderers.FrontMatter // builtin template front matter, nil without a front matter block
+	var matchReport *renderers.MatchReport // user template --report match report, nil when undefined
+	var rendererr error
+	if userTemplateMode() {
+		// if user specified --find flag with appropriate argument or substitution rules, perform user template rendering
+		renderedStringPointer, matchReport, rendererr = renderers.RenderFromRemoteUserTemplateWithRules(templateURL, userRules(replaceString), *reportFlag)
+	} else {
+		// otherwise perform builtin template rendering
+		renderedStringPointer, frontMatter, rendererr = renderers.RenderFromRemoteInkTemplateWithFrontMatter(templateURL, replaceString)
+	}
+	if rendererr != nil {
+		return statusFailed, "", nil, rendererr
+	}
+	status, outPath, writeerr := writeRendered(templateURL, *stdOutFlag, renderedStringPointer, frontMatter)
+	return status, outPath, matchReport, writeerr
+}
+
+// loadTemplateData reads the --data template data file into the builtin template renderer data
+func loadTemplateData() error {
+	if len(*dataPath) == 0 {
+		return nil
+	}
+	templateData, dataerr := inkio.ReadDataFile(*dataPath)
+	if dataerr != nil {
+		return dataerr
 	}
+	renderers.TemplateData = templateData
 	return nil
 }
 
-// renderRemote handles remote template file rendering, called in parallel fashion from main function
-func renderRemote(templateURL string, replaceString *string, stdOutFlag *bool) error {
-	// if user specified --find flag with appropriate argument, perform user template rendering
-	if len(*findString) > 0 {
-		renderedStringPointer, rendererr := renderers.RenderFromRemoteUserTemplate(templateURL, findString, replaceString)
-		if rendererr != nil {
-			return rendererr
+// watchTemplates polls the local templates in templatePaths, the local files that they include, and a local --data
+// template data file for changes and re-renders the affected templates.  Renders start after a burst of file changes
+// is followed by a quiet period of watchDebounce.  This function does not return
+func watchTemplates(templatePaths []string, replaceString *string, stdOutFlag *bool) {
+	dependents := watchDependents(templatePaths)
+	stamps := utilities.StatFiles(watchPaths(dependents))
+	pending := make(map[string]bool) // changed file paths that have not been rendered yet
+	var lastChange time.Time
+	if *formatString == "text" {
+		os.Stdout.WriteString("[ink] Watching for template changes. Press Ctrl+C to stop.\n")
+	}
+
+	for {
+		time.Sleep(watchInterval)
+		currentStamps := utilities.StatFiles(watchPaths(dependents))
+		changed := utilities.ChangedFiles(stamps, currentStamps)
+		stamps = currentStamps
+		if len(changed) > 0 {
+			for _, changedPath := range changed {
+				pending[changedPath] = true
+			}
+			lastChange = time.Now()
+			continue
 		}
-		templatePath, urlerr := utilities.GetURLFilePath(templateURL)
-		if urlerr != nil {
-			return urlerr
+		if len(pending) == 0 || time.Since(lastChange) < watchDebounce {
+			continue
+		}
+
+		if pending[*dataPath] {
+			if dataerr := loadTemplateData(); dataerr != nil {
+				os.Stderr.WriteString("[ink] ERROR: Unable to read template data file '" + *dataPath + "'. " + fmt.Sprintf("%v\n", dataerr))
+			}
 		}
-		writeerr := inkio.WriteString(templatePath, *stdOutFlag, renderedStringPointer)
-		if writeerr != nil {
-			return writeerr
+		affected := make(map[string]bool)
+		for changedPath := range pending {
+			for _, templatePath := range dependents[changedPath] {
+				affected[templatePath] = true
+			}
+		}
+		for _, templatePath := range templatePaths {
+			if !affected[templatePath] {
+				continue
+			}
+			start := time.Now()
+			status, outPath, matchReport, err := renderLocal(templatePath, replaceString, stdOutFlag)
+			reportRender(templatePath, status, outPath, matchReport, err, time.Since(start))
+		}
+		pending = make(map[string]bool)
+
+		// template edits can add or remove included files, watch newly included files from their current state
+		dependents = watchDependents(templatePaths)
+		for watchPath, stamp := range utilities.StatFiles(watchPaths(dependents)) {
+			if _, ok := stamps[watchPath]; !ok {
+				stamps[watchPath] = stamp
+			}
 		}
-		return nil
 	}
-	// otherwise perform builtin template rendering
-	renderedStringPointer, rendererr := renderers.RenderFromRemoteInkTemplate(templateURL, replaceString)
-	if rendererr != nil {
-		return rendererr
+}
+
+// watchDependents maps the local file paths that are watched in --watch mode to the templates in templatePaths that
+// must be re-rendered when they change
+func watchDependents(templatePaths []string) map[string][]string {
+	dependents := make(map[string][]string)
+	for _, templatePath := range templatePaths {
+		dependencies := []string{templatePath}
+		if !userTemplateMode() { // builtin templates support {{ include "path" }} template function calls
+			dependencies = renderers.TemplateDependencies(templatePath)
+		}
+		for _, dependency := range dependencies {
+			dependents[dependency] = append(dependents[dependency], templatePath)
+		}
+	}
+	if len(*dataPath) > 0 && !inkio.IsURL(*dataPath) {
+		dependents[*dataPath] = append(dependents[*dataPath], templatePaths...)
+	}
+	return dependents
+}
+
+// watchPaths returns the watched file paths in dependents
+func watchPaths(dependents map[string][]string) []string {
+	paths := make([]string, 0, len(dependents))
+	for path := range dependents {
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// writeRendered writes the rendered string renderedStringPointer for the local template path or remote template URL
+// templatePath to its outfile or to the standard output stream and returns the render status, outfile path, and
+// error.  The output path and file mode that are defined in the template front matter frontMatter (nil = undefined)
+// take precedence over the command line options.  In --dry-run mode, the rendered string is compared with the
+// existing outfile and a unified diff of the outfile changes is written to the standard output stream
+func writeRendered(templatePath string, stdOutFlag bool, renderedStringPointer *string, frontMatter *renderers.FrontMatter) (renderStatus, string, error) {
+	outPath, patherr := outFilePath(templatePath)
+	if patherr != nil {
+		return statusFailed, "", patherr
+	}
+	attributes := outFileAttributes(templatePath)
+	if frontMatter != nil {
+		if len(frontMatter.Output) > 0 { // front matter output paths are below the default outfile directory
+			outPath = filepath.Join(filepath.Dir(outPath), frontMatter.Output)
+		}
+		if frontMatter.Mode != 0 {
+			attributes.Mode = frontMatter.Mode
+		}
 	}
-	templatePath, urlerr := utilities.GetURLFilePath(templateURL)
-	if urlerr != nil {
-		return urlerr
+
+	if *dryRunFlag {
+		outText, readerr := inkio.ReadFileToString(outPath)
+		oldName := outPath
+		if readerr != nil {
+			if !os.IsNotExist(readerr) {
+				return statusFailed, "", readerr
+			}
+			oldName = "/dev/null" // outfile does not exist
+		} else if outText == *renderedStringPointer {
+			return statusUnchanged, outPath, nil
+		}
+		diff := utilities.UnifiedDiff(oldName, outPath, outText, *renderedStringPointer)
+		if len(diff) == 0 { // empty rendered string and missing outfile
+			diff = "--- " + oldName + "\n+++ " + outPath + "\n"
+		}
+		if *formatString == "text" { // the JSON record status reports the change in --format=json mode
+			stdoutMutex.Lock()
+			os.Stdout.WriteString(diff)
+			stdoutMutex.Unlock()
+		}
+		return statusChanged, outPath, nil
 	}
-	writeerr := inkio.WriteString(templatePath, *stdOutFlag, renderedStringPointer)
+
+	if len(inPlace.suffix) > 0 {
+		// keep a backup copy of files that are edited in place, unchanged files are not backed up
+		outText, readerr := inkio.ReadFileToString(outPath)
+		if readerr != nil {
+			return statusFailed, "", readerr
+		}
+		if outText != *renderedStringPointer {
+			if backuperr := inkio.CopyFile(outPath, outPath+inPlace.suffix); backuperr != nil {
+				return statusFailed, "", backuperr
+			}
+		}
+	}
+	written, writeerr := inkio.WriteStringToPathWithAttributes(outPath, stdOutFlag, renderedStringPointer, attributes)
 	if writeerr != nil {
-		return writeerr
+		return statusFailed, "", writeerr
 	}
-	return nil
+	if stdOutFlag {
+		return statusRendered, "", nil
+	}
+	if !written {
+		return statusUnchanged, outPath, nil // existing outfile content is identical to the rendered string
+	}
+	return statusRendered, outPath, nil
+}
+
+// outFilePath returns the outfile path for the local template path or remote template URL templatePath.  Local
+// templates are their own outfiles in --in-place mode.  Otherwise, local outfiles are written next to the template
+// and remote outfiles are written to the current working directory unless the --outdir option is defined.  With
+// --outdir, outfiles are written below the output directory on a path that mirrors the template source tree
+// (relative to the template directory argument for templates found in template directories) or the template URL path
+func outFilePath(templatePath string) (string, error) {
+	if inPlace.enabled {
+		return templatePath, nil
+	}
+	if inkio.IsURL(templatePath) {
+		if len(*outDir) == 0 {
+			urlFilePath, urlerr := utilities.GetURLFilePath(templatePath)
+			return inkio.OutFilePath(urlFilePath), urlerr
+		}
+		urlFilePath, urlerr := utilities.GetURLMirroredPath(templatePath)
+		if urlerr != nil {
+			return "", urlerr
+		}
+		return inkio.OutFilePath(filepath.Join(*outDir, urlFilePath)), nil
+	}
+
+	if len(*outDir) == 0 {
+		return inkio.OutFilePath(templatePath), nil
+	}
+	relPath := templatePath
+	if dirRoot, ok := templateDirRoots[templatePath]; ok {
+		if dirRelPath, relerr := filepath.Rel(dirRoot, templatePath); relerr == nil {
+			relPath = dirRelPath
+		}
+	}
+	return inkio.OutFilePath(filepath.Join(*outDir, utilities.MirroredPath(relPath))), nil
+}
+
+// outFileAttributes returns the file attributes of the outfile for the local template path or remote template URL
+// templatePath.  Local template file modes and ownership are copied to the outfile unless the --no-preserve-mode
+// option is used, and the --mode option value overrides the file mode
+func outFileAttributes(templatePath string) *inkio.FileAttributes {
+	attributes := &inkio.FileAttributes{Mode: outFileMode}
+	if !*noPreserveModeFlag && !inkio.IsURL(templatePath) {
+		if fileInfo, staterr := os.Stat(templatePath); staterr == nil {
+			attributes.OwnerFrom = fileInfo
+			if attributes.Mode == 0 {
+				attributes.Mode = fileInfo.Mode().Perm()
+			}
+		}
+	}
+	return attributes
 }
@@ -8,8 +8,11 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"runtime"
 	"strings"
 	"testing"
+
+	"github.com/chrissimpkins/ink/renderers"
 )
 
 // test version string formatting
@@ -80,6 +83,151 @@ func TestDefaultReplaceString(t *testing.T) {
 	}
 }
 
+func TestDefaultNumberedReplaceStrings(t *testing.T) {
+	for i, numberedReplaceString := range numberedReplaceStrings {
+		if len(*numberedReplaceString) > 0 {
+			t.Errorf("[FAIL] Expected empty --replace%d value by default, received string %s", i+2, *numberedReplaceString)
+		}
+	}
+}
+
+func TestDefaultDataPath(t *testing.T) {
+	if len(*dataPath) > 0 {
+		t.Errorf("[FAIL] Expected empty *dataPath value by default, received string %s", *dataPath)
+	}
+}
+
+func TestDefaultEnvPrefix(t *testing.T) {
+	if len(*envPrefix) > 0 {
+		t.Errorf("[FAIL] Expected empty *envPrefix value by default, received string %s", *envPrefix)
+	}
+}
+
+func TestDefaultReportFlags(t *testing.T) {
+	if *reportFlag == true || *reportPreviewFlag == true {
+		t.Errorf("[FAIL] Expected *reportFlag == false and *reportPreviewFlag == false as default, got true")
+	}
+}
+
+func TestDefaultStrictFlag(t *testing.T) {
+	if *strictFlag == true {
+		t.Errorf("[FAIL] Expected *strictFlag == false as default, got true")
+	}
+}
+
+func TestDefaultStrictEnvFlag(t *testing.T) {
+	if *strictEnvFlag == true {
+		t.Errorf("[FAIL] Expected *strictEnvFlag == false as default, got true")
+	}
+}
+
+func TestDefaultIncludeGlobs(t *testing.T) {
+	if len(includeGlobs) > 0 {
+		t.Errorf("[FAIL] Expected empty includeGlobs value by default, received %v", includeGlobs)
+	}
+}
+
+func TestDefaultExcludeGlobs(t *testing.T) {
+	if len(excludeGlobs) > 0 {
+		t.Errorf("[FAIL] Expected empty excludeGlobs value by default, received %v", excludeGlobs)
+	}
+}
+
+func TestDefaultFollowSymlinksFlag(t *testing.T) {
+	if *followSymlinksFlag == true {
+		t.Errorf("[FAIL] Expected *followSymlinksFlag == false as default, got true")
+	}
+}
+
+func TestStringListFlag(t *testing.T) {
+	var testFlag stringListFlag
+	testFlag.Set("*.txt.in")
+	testFlag.Set("*.md.in")
+	if len(testFlag) != 2 || testFlag.String() != "*.txt.in,*.md.in" {
+		t.Errorf("[FAIL] Expected stringListFlag to maintain all definitions in order, received %v", testFlag)
+	}
+}
+
+func TestDefaultOutDir(t *testing.T) {
+	if len(*outDir) > 0 {
+		t.Errorf("[FAIL] Expected empty *outDir value by default, received string %s", *outDir)
+	}
+}
+
+func TestDefaultFileModeString(t *testing.T) {
+	if len(*fileModeString) > 0 {
+		t.Errorf("[FAIL] Expected empty *fileModeString value by default, received string %s", *fileModeString)
+	}
+}
+
+func TestDefaultNoPreserveModeFlag(t *testing.T) {
+	if *noPreserveModeFlag == true {
+		t.Errorf("[FAIL] Expected *noPreserveModeFlag == false as default, got true")
+	}
+}
+
+func TestDefaultDelimsString(t *testing.T) {
+	if len(*delimsString) > 0 {
+		t.Errorf("[FAIL] Expected empty *delimsString value by default, received string %s", *delimsString)
+	}
+}
+
+func TestDefaultDryRunFlag(t *testing.T) {
+	if *dryRunFlag == true {
+		t.Errorf("[FAIL] Expected *dryRunFlag == false as default, got true")
+	}
+}
+
+func TestDefaultWatchFlag(t *testing.T) {
+	if *watchFlag == true {
+		t.Errorf("[FAIL] Expected *watchFlag == false as default, got true")
+	}
+}
+
+func TestWatchOptionsMessage(t *testing.T) {
+	templatePaths := []string{filepath.Join("testfiles", "template_1.txt.in")}
+	tests := []struct {
+		watch         bool
+		dryRun        bool
+		templatePaths []string
+		expectError   bool
+	}{
+		{false, false, nil, false},
+		{false, true, templatePaths, false},
+		{true, false, templatePaths, false},
+		{true, false, nil, true},
+		{true, true, templatePaths, true},
+	}
+	for _, test := range tests {
+		*watchFlag, *dryRunFlag = test.watch, test.dryRun
+		message := watchOptionsMessage(test.templatePaths)
+		*watchFlag, *dryRunFlag = false, false // reset to default values or this interferes with other tests
+		if (len(message) > 0) != test.expectError {
+			t.Errorf("[FAIL] Unexpected --watch option validation result for --watch=%t --dry-run=%t with %d templates: '%s'", test.watch, test.dryRun, len(test.templatePaths), message)
+		}
+	}
+}
+
+func TestDefaultFormatString(t *testing.T) {
+	if *formatString != "text" {
+		t.Errorf("[FAIL] Expected *formatString == 'text' as default, received string %s", *formatString)
+	}
+}
+
+func TestRenderStatusString(t *testing.T) {
+	tests := map[renderStatus]string{
+		statusRendered:  "rendered",
+		statusFailed:    "failed",
+		statusChanged:   "changed",
+		statusUnchanged: "unchanged",
+	}
+	for status, expected := range tests {
+		if status.String() != expected {
+			t.Errorf("[FAIL] Expected render status name '%s', received '%s'", expected, status.String())
+		}
+	}
+}
+
 func TestDefaultLintFlag(t *testing.T) {
 	if *lintFlag == true {
 		t.Errorf("[FAIL] Expected *lintFlag == false as default, got true")
@@ -98,6 +246,20 @@ func TestDefaultTrimNLFlag(t *testing.T) {
 	}
 }
 
+func TestTrimReplaceStrings(t *testing.T) {
+	replaceString := "abcd123\n"
+	renderers.NumberedReplaceStrings = map[string]string{"Two": "2017\n\n", "Three": "three"}
+	trimReplaceStrings(&replaceString)
+	numbered := renderers.NumberedReplaceStrings
+	renderers.NumberedReplaceStrings = map[string]string{} // reset to default value or this interferes with other tests
+	if replaceString != "abcd123" {
+		t.Errorf("[FAIL] Expected the trimmed replacement string 'abcd123', received '%s'", replaceString)
+	}
+	if numbered["Two"] != "2017" || numbered["Three"] != "three" {
+		t.Errorf("[FAIL] Expected the trimmed numbered replacement strings 'Two' = '2017' and 'Three' = 'three', received %q", numbered)
+	}
+}
+
 // --------------------------------------------
 // test render functions
 // --------------------------------------------
@@ -108,7 +270,7 @@ func TestRenderLocalBuiltinTemplateFileWrite(t *testing.T) {
 	replaceString := "test"
 	expectedString := "sha=test test=test"
 	mockStdoutFlag := false
-	fileerr := renderLocal(templatePath, &replaceString, &mockStdoutFlag)
+	_, _, _, fileerr := renderLocal(templatePath, &replaceString, &mockStdoutFlag)
 
 	_, staterr := os.Stat(outPath)
 	if !os.IsNotExist(staterr) {
@@ -126,6 +288,269 @@ func TestRenderLocalBuiltinTemplateFileWrite(t *testing.T) {
 	}
 }
 
+func TestRenderLocalBuiltinTemplateOutDirWrite(t *testing.T) {
+	templatePath := filepath.Join("testfiles", "dir", "sub", "b.txt.in")
+	mockOutDir := "testing_outdir"
+	replaceString := "test"
+	expectedString := "b=test"
+	mockStdoutFlag := false
+	defer os.RemoveAll(mockOutDir)
+
+	*outDir = mockOutDir
+	templateDirRoots[templatePath] = filepath.Join("testfiles", "dir")
+	_, _, _, fileerr := renderLocal(templatePath, &replaceString, &mockStdoutFlag)
+	// reset to default values or this interferes with other tests
+	*outDir = ""
+	delete(templateDirRoots, templatePath)
+	if fileerr != nil {
+		t.Errorf("[FAIL] Unexpected error raised during execution: %v", fileerr)
+	}
+
+	outPath := filepath.Join(mockOutDir, "sub", "b.txt")
+	readstring, readerr := ioutil.ReadFile(outPath)
+	if readerr != nil {
+		t.Errorf("[FAIL] Unable to read expected text file %s in test. %v", outPath, readerr)
+	}
+	if string(readstring) != expectedString {
+		t.Errorf("[FAIL] Expected to read '%s' from test file and actually read '%s'", expectedString, readstring)
+	}
+}
+
+func TestRenderLocalTemplateFileModeWrite(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file permission bits are not supported on Windows")
+	}
+	tempDir, tempErr := ioutil.TempDir("", "ink")
+	if tempErr != nil {
+		t.Fatalf("[FAIL] Unable to create temporary directory: %v", tempErr)
+	}
+	defer os.RemoveAll(tempDir)
+	templatePath := filepath.Join(tempDir, "deploy.sh.in")
+	outPath := filepath.Join(tempDir, "deploy.sh")
+	ioutil.WriteFile(templatePath, []byte("echo {{ ink }}"), 0755)
+	replaceString := "test"
+	mockStdoutFlag := false
+
+	tests := []struct {
+		mode           os.FileMode
+		noPreserveMode bool
+		expected       os.FileMode
+	}{
+		{0, false, 0755},    // template file mode is copied by default
+		{0600, false, 0600}, // --mode overrides the template file mode
+		{0, true, 0600},     // --no-preserve-mode maintains the existing outfile mode
+		{0640, true, 0640},  // --mode with --no-preserve-mode
+	}
+
+	for _, testcase := range tests {
+		outFileMode = testcase.mode
+		*noPreserveModeFlag = testcase.noPreserveMode
+		_, _, _, fileerr := renderLocal(templatePath, &replaceString, &mockStdoutFlag)
+		// reset to default values or this interferes with other tests
+		outFileMode = 0
+		*noPreserveModeFlag = false
+		if fileerr != nil {
+			t.Errorf("[FAIL] Unexpected error raised during execution: %v", fileerr)
+		}
+		fileInfo, staterr := os.Stat(outPath)
+		if staterr != nil {
+			t.Errorf("[FAIL] The expected file write for the test was not found. %v", staterr)
+		} else if fileInfo.Mode().Perm() != testcase.expected {
+			t.Errorf("[FAIL] Expected outfile mode %v, received %v", testcase.expected, fileInfo.Mode().Perm())
+		}
+	}
+}
+
+func TestRenderLocalTemplateFrontMatterOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file permission bits are not supported on Windows")
+	}
+	tempDir, tempErr := ioutil.TempDir("", "ink")
+	if tempErr != nil {
+		t.Fatalf("[FAIL] Unable to create temporary directory: %v", tempErr)
+	}
+	defer os.RemoveAll(tempDir)
+	templatePath := filepath.Join(tempDir, "deploy.sh.in")
+	outPath := filepath.Join(tempDir, "bin", "deploy")
+	os.Mkdir(filepath.Join(tempDir, "bin"), 0755)
+	ioutil.WriteFile(templatePath, []byte("---\noutput: bin/deploy\nmode: \"0750\"\n---\necho {{ ink }}"), 0644)
+	replaceString := "test"
+	mockStdoutFlag := false
+
+	// front matter mode takes precedence over --mode
+	outFileMode = 0600
+	status, renderedPath, _, fileerr := renderLocal(templatePath, &replaceString, &mockStdoutFlag)
+	outFileMode = 0 // reset to default value or this interferes with other tests
+	if fileerr != nil {
+		t.Errorf("[FAIL] Unexpected error raised during execution: %v", fileerr)
+	}
+	if status != statusRendered || renderedPath != outPath {
+		t.Errorf("[FAIL] Expected render status %d and outfile %s, received %d and %s", statusRendered, outPath, status, renderedPath)
+	}
+	fileInfo, staterr := os.Stat(outPath)
+	if staterr != nil {
+		t.Errorf("[FAIL] The expected file write for the test was not found. %v", staterr)
+	} else if fileInfo.Mode().Perm() != 0750 {
+		t.Errorf("[FAIL] Expected outfile mode %v, received %v", os.FileMode(0750), fileInfo.Mode().Perm())
+	}
+	if outText, _ := ioutil.ReadFile(outPath); string(outText) != "echo test" {
+		t.Errorf("[FAIL] Expected outfile text 'echo test', received '%s'", outText)
+	}
+	if _, staterr := os.Stat(filepath.Join(tempDir, "deploy.sh")); !os.IsNotExist(staterr) {
+		t.Errorf("[FAIL] Expected no outfile at the default outfile path")
+	}
+}
+
+func TestRenderLocalTemplateFrontMatterOutputEscapeFails(t *testing.T) {
+	tempDir, tempErr := ioutil.TempDir("", "ink")
+	if tempErr != nil {
+		t.Fatalf("[FAIL] Unable to create temporary directory: %v", tempErr)
+	}
+	defer os.RemoveAll(tempDir)
+	srcDir := filepath.Join(tempDir, "src", "sub")
+	os.MkdirAll(srcDir, 0755)
+	templatePath := filepath.Join(srcDir, "escape.txt.in")
+	ioutil.WriteFile(templatePath, []byte("---\noutput: ../../../escaped.txt\n---\n{{ ink }}"), 0644)
+	replaceString := "test"
+	mockStdoutFlag := false
+
+	*outDir = filepath.Join(tempDir, "build")
+	templateDirRoots[templatePath] = filepath.Join(tempDir, "src")
+	status, renderedPath, _, fileerr := renderLocal(templatePath, &replaceString, &mockStdoutFlag)
+	// reset to default values or this interferes with other tests
+	*outDir = ""
+	delete(templateDirRoots, templatePath)
+
+	if fileerr == nil || status != statusFailed || renderedPath != "" {
+		t.Errorf("[FAIL] Expected an escaping front matter output path to fail the render, received %d '%s' %v", status, renderedPath, fileerr)
+	}
+	for _, escapedPath := range []string{filepath.Join(tempDir, "escaped.txt"), filepath.Join(filepath.Dir(tempDir), "escaped.txt")} {
+		if _, staterr := os.Stat(escapedPath); !os.IsNotExist(staterr) {
+			t.Errorf("[FAIL] Expected no outfile at the escaped path %s", escapedPath)
+		}
+	}
+}
+
+func TestRenderLocalTemplateUnchangedSkipsWrite(t *testing.T) {
+	tempDir, tempErr := ioutil.TempDir("", "ink")
+	if tempErr != nil {
+		t.Fatalf("[FAIL] Unable to create temporary directory: %v", tempErr)
+	}
+	defer os.RemoveAll(tempDir)
+	templatePath := filepath.Join(tempDir, "version.txt.in")
+	ioutil.WriteFile(templatePath, []byte("version={{ ink }}"), 0644)
+	replaceString := "test"
+	mockStdoutFlag := false
+
+	expected := []renderStatus{statusRendered, statusUnchanged}
+	for _, expectedStatus := range expected {
+		status, _, _, fileerr := renderLocal(templatePath, &replaceString, &mockStdoutFlag)
+		if fileerr != nil {
+			t.Errorf("[FAIL] Unexpected error raised during execution: %v", fileerr)
+		}
+		if status != expectedStatus {
+			t.Errorf("[FAIL] Expected render status %d, received %d", expectedStatus, status)
+		}
+	}
+}
+
+func TestRenderLocalTemplateDryRun(t *testing.T) {
+	tempDir, tempErr := ioutil.TempDir("", "ink")
+	if tempErr != nil {
+		t.Fatalf("[FAIL] Unable to create temporary directory: %v", tempErr)
+	}
+	defer os.RemoveAll(tempDir)
+	templatePath := filepath.Join(tempDir, "version.txt.in")
+	outPath := filepath.Join(tempDir, "version.txt")
+	ioutil.WriteFile(templatePath, []byte("version={{ ink }}"), 0644)
+	replaceString := "test"
+	mockStdoutFlag := false
+
+	tests := []struct {
+		outText  string // existing outfile text, no outfile when empty
+		expected renderStatus
+	}{
+		{"", statusChanged},
+		{"version=old", statusChanged},
+		{"version=test", statusUnchanged},
+	}
+
+	for _, testcase := range tests {
+		os.Remove(outPath)
+		if len(testcase.outText) > 0 {
+			ioutil.WriteFile(outPath, []byte(testcase.outText), 0644)
+		}
+		*dryRunFlag = true
+		status, _, _, fileerr := renderLocal(templatePath, &replaceString, &mockStdoutFlag)
+		*dryRunFlag = false // reset to default value or this interferes with other tests
+		if fileerr != nil {
+			t.Errorf("[FAIL] Unexpected error raised during execution: %v", fileerr)
+		}
+		if status != testcase.expected {
+			t.Errorf("[FAIL] Expected render status %d for outfile text '%s', received %d", testcase.expected, testcase.outText, status)
+		}
+		// the outfile must not be written in dry run mode
+		readstring, _ := ioutil.ReadFile(outPath)
+		if string(readstring) != testcase.outText {
+			t.Errorf("[FAIL] Expected outfile text '%s' to be unchanged in dry run, read '%s'", testcase.outText, readstring)
+		}
+	}
+}
+
+func TestWatchDependents(t *testing.T) {
+	includePath := filepath.Join("testfiles", "include", "template_include.txt.in")
+	footerPath := filepath.Join("testfiles", "include", "footer.txt.in")
+	templatePath := filepath.Join("testfiles", "template_1.txt.in")
+	mockDataPath := filepath.Join("testfiles", "data.json")
+
+	*dataPath = mockDataPath
+	dependents := watchDependents([]string{includePath, templatePath})
+	*dataPath = "" // reset to default value or this interferes with other tests
+	tests := []struct {
+		watchpath string
+		expected  string
+	}{
+		{includePath, includePath},
+		{footerPath, includePath},
+		{templatePath, templatePath},
+		{mockDataPath, includePath + "," + templatePath},
+	}
+	for _, testcase := range tests {
+		if strings.Join(dependents[testcase.watchpath], ",") != testcase.expected {
+			t.Errorf("[FAIL] Expected watched file %s to re-render %s, received %v", testcase.watchpath, testcase.expected, dependents[testcase.watchpath])
+		}
+	}
+	if len(dependents) != 6 {
+		t.Errorf("[FAIL] Expected 6 watched files, received %d: %v", len(dependents), dependents)
+	}
+}
+
+func TestOutFilePath(t *testing.T) {
+	tests := []struct {
+		templatepath string
+		outdir       string
+		expected     string
+	}{
+		{filepath.Join("testfiles", "template_1.txt.in"), "", filepath.Join("testfiles", "template_1.txt")},
+		{filepath.Join("testfiles", "template_1.txt.in"), "build", filepath.Join("build", "testfiles", "template_1.txt")},
+		{filepath.Join("..", "template_1.txt.in"), "build", filepath.Join("build", "__parent__", "template_1.txt")},
+		{"https://test.com/templates/template_1.txt.in", "", "template_1.txt"},
+		{"https://test.com/templates/template_1.txt.in", "build", filepath.Join("build", "templates", "template_1.txt")},
+	}
+
+	for _, testcase := range tests {
+		*outDir = testcase.outdir
+		response, err := outFilePath(testcase.templatepath)
+		*outDir = "" // reset to default value or this interferes with other tests
+		if err != nil {
+			t.Errorf("[FAIL] Unexpected error raised during execution: %v", err)
+		}
+		if response != testcase.expected {
+			t.Errorf("[FAIL] Expected outFilePath to return '%s' for '%s', received '%s'", testcase.expected, testcase.templatepath, response)
+		}
+	}
+}
+
 func TestRenderLocalUserTemplateFileWrite(t *testing.T) {
 	templatePath := filepath.Join("testfiles", "template_3.txt.in")
 	outPath := filepath.Join("testfiles", "template_3.txt")
@@ -133,7 +558,7 @@ func TestRenderLocalUserTemplateFileWrite(t *testing.T) {
 	*findString = "[[user]]"
 	expectedString := "sha=test test=test"
 	mockStdoutFlag := false
-	fileerr := renderLocal(templatePath, &replaceString, &mockStdoutFlag)
+	_, _, _, fileerr := renderLocal(templatePath, &replaceString, &mockStdoutFlag)
 	*findString = "" // reset to default value or this interferes with other tests
 
 	_, staterr := os.Stat(outPath)
@@ -152,6 +577,118 @@ func TestRenderLocalUserTemplateFileWrite(t *testing.T) {
 	}
 }
 
+func TestRenderLocalUserTemplateSubRulesFileWrite(t *testing.T) {
+	templatePath := filepath.Join("testfiles", "template_3.txt.in")
+	outPath := filepath.Join("testfiles", "template_3.txt")
+	replaceString := "test"
+	*findString = "[[user]]"
+	subRules = []renderers.SubRule{{Find: "TEST", Replace: "check", IgnoreCase: true, FirstOnly: true}, {Find: "sha", Replace: "commit"}}
+	expectedString := "commit=check test=test"
+	mockStdoutFlag := false
+	_, _, _, fileerr := renderLocal(templatePath, &replaceString, &mockStdoutFlag)
+	// reset to default values or this interferes with other tests
+	*findString = ""
+	subRules = nil
+
+	readstring, readerr := ioutil.ReadFile(outPath)
+	if readerr != nil {
+		t.Errorf("[FAIL] The expected file write for the test was not found. %v %v", readerr, fileerr)
+	} else if string(readstring) != expectedString {
+		t.Errorf("[FAIL] Expected to read '%s' from test file and actually read '%s'", expectedString, readstring)
+	}
+	os.Remove(outPath)
+}
+
+func TestInPlaceFlag(t *testing.T) {
+	if inPlace.enabled || len(inPlace.suffix) > 0 {
+		t.Errorf("[FAIL] Expected the --in-place option to be undefined by default")
+	}
+	tests := []struct {
+		value    string
+		expected inPlaceFlag
+	}{
+		{"true", inPlaceFlag{enabled: true}},
+		{".bak", inPlaceFlag{enabled: true, suffix: ".bak"}},
+		{"false", inPlaceFlag{}},
+	}
+	var flagValue inPlaceFlag
+	for _, test := range tests {
+		flagValue.Set(test.value)
+		if flagValue != test.expected {
+			t.Errorf("[FAIL] Expected --in-place=%s flag value %+v, received %+v", test.value, test.expected, flagValue)
+		}
+	}
+}
+
+func TestRenderLocalUserTemplateInPlace(t *testing.T) {
+	tempDir, tempErr := ioutil.TempDir("", "ink")
+	if tempErr != nil {
+		t.Fatalf("[FAIL] Unable to create temporary directory: %v", tempErr)
+	}
+	defer os.RemoveAll(tempDir)
+	filePath := filepath.Join(tempDir, "config.txt")
+	ioutil.WriteFile(filePath, []byte("version=1.0.0"), 0644)
+	replaceString := "2.0.0"
+	*findString = "1.0.0"
+	inPlace = inPlaceFlag{enabled: true, suffix: ".orig"}
+	mockStdoutFlag := false
+	status, outPath, _, fileerr := renderLocal(filePath, &replaceString, &mockStdoutFlag)
+	unchangedStatus, _, _, unchangederr := renderLocal(filePath, &replaceString, &mockStdoutFlag)
+	// reset to default values or this interferes with other tests
+	*findString = ""
+	inPlace = inPlaceFlag{}
+
+	if fileerr != nil || unchangederr != nil {
+		t.Errorf("[FAIL] Expected nil error values for in-place renders, received %v %v", fileerr, unchangederr)
+	}
+	if status != statusRendered || outPath != filePath || unchangedStatus != statusUnchanged {
+		t.Errorf("[FAIL] Unexpected in-place render statuses %v and %v with outfile path '%s'", status, unchangedStatus, outPath)
+	}
+	readstring, _ := ioutil.ReadFile(filePath)
+	if string(readstring) != "version=2.0.0" {
+		t.Errorf("[FAIL] Expected to read 'version=2.0.0' from the edited file and actually read '%s'", readstring)
+	}
+	backupstring, _ := ioutil.ReadFile(filePath + ".orig")
+	if string(backupstring) != "version=1.0.0" {
+		t.Errorf("[FAIL] Expected to read 'version=1.0.0' from the backup file and actually read '%s'", backupstring)
+	}
+}
+
+func TestDefaultRegexFlags(t *testing.T) {
+	if *regexFlag || *ignoreCaseFlag || *multilineFlag || *dotAllFlag {
+		t.Errorf("[FAIL] Expected the --regex, --ignore-case, --multiline, and --dotall flags == false as default, got true")
+	}
+}
+
+func TestDefaultScopeOptions(t *testing.T) {
+	if len(*linesString) > 0 || len(*regionStartString) > 0 || len(*regionEndString) > 0 || *nthMatch != 0 {
+		t.Errorf("[FAIL] Expected the --lines, --region-start, --region-end, and --nth options to be undefined by default")
+	}
+}
+
+func TestFindRule(t *testing.T) {
+	*findString, *ignoreCaseFlag = "ink", true
+	replaceString := "test"
+	rule := findRule(&replaceString)
+	*findString, *ignoreCaseFlag = "", false // reset to default values or this interferes with other tests
+	expected := renderers.SubRule{Find: "ink", Replace: "test", Mode: renderers.ReplaceExpand, IgnoreCase: true}
+	if rule != expected {
+		t.Errorf("[FAIL] Expected --find rule %+v, received %+v", expected, rule)
+	}
+}
+
+func TestDefaultReplaceModeString(t *testing.T) {
+	if *replaceModeString != renderers.ReplaceExpand {
+		t.Errorf("[FAIL] Expected *replaceModeString == '%s' as default, received '%s'", renderers.ReplaceExpand, *replaceModeString)
+	}
+}
+
+func TestDefaultSubRules(t *testing.T) {
+	if len(subDefinitions) > 0 || len(*subFilePath) > 0 || userTemplateMode() {
+		t.Errorf("[FAIL] Expected no --sub and --sub-file definitions by default")
+	}
+}
+
 func TestRenderLocalBuiltinTemplateStdout(t *testing.T) {
 	templatePath := filepath.Join("testfiles", "template_1.txt.in")
 	testString := "test"
@@ -174,7 +711,7 @@ func TestRenderLocalBuiltinTemplateStdout(t *testing.T) {
 		outC <- buf.String()
 	}()
 
-	fileerr := renderLocal(templatePath, &testString, &mockStdoutFlag)
+	_, _, _, fileerr := renderLocal(templatePath, &testString, &mockStdoutFlag)
 
 	// back to normal state
 	w.Close()
@@ -213,7 +750,7 @@ func TestRenderLocalUserTemplateStdout(t *testing.T) {
 		outC <- buf.String()
 	}()
 
-	fileerr := renderLocal(templatePath, &testString, &mockStdoutFlag)
+	_, _, _, fileerr := renderLocal(templatePath, &testString, &mockStdoutFlag)
 	*findString = "" // reset to default value or this interferes with other tests
 
 	// back to normal state
@@ -236,7 +773,7 @@ func TestRenderRemoteBuiltinTemplateFileWrite(t *testing.T) {
 	replaceString := "test"
 	expectedString := "sha=test test=test"
 	mockStdoutFlag := false
-	fileerr := renderRemote(templatePath, &replaceString, &mockStdoutFlag)
+	_, _, _, fileerr := renderRemote(templatePath, &replaceString, &mockStdoutFlag)
 
 	_, staterr := os.Stat(outPath)
 	if !os.IsNotExist(staterr) {
@@ -261,7 +798,7 @@ func TestRenderRemoteUserTemplateFileWrite(t *testing.T) {
 	*findString = "[[user]]"
 	expectedString := "sha=test test=test"
 	mockStdoutFlag := false
-	fileerr := renderRemote(templatePath, &replaceString, &mockStdoutFlag)
+	_, _, _, fileerr := renderRemote(templatePath, &replaceString, &mockStdoutFlag)
 	*findString = "" // reset to default value or this interferes with other tests
 
 	_, staterr := os.Stat(outPath)
@@ -302,7 +839,7 @@ func TestRenderRemoteBuiltinTemplateStdout(t *testing.T) {
 		outC <- buf.String()
 	}()
 
-	fileerr := renderRemote(templatePath, &testString, &mockStdoutFlag)
+	_, _, _, fileerr := renderRemote(templatePath, &testString, &mockStdoutFlag)
 
 	// back to normal state
 	w.Close()
@@ -341,7 +878,7 @@ func TestRenderRemoteUserTemplateStdout(t *testing.T) {
 		outC <- buf.String()
 	}()
 
-	fileerr := renderRemote(templatePath, &testString, &mockStdoutFlag)
+	_, _, _, fileerr := renderRemote(templatePath, &testString, &mockStdoutFlag)
 	*findString = "" // reset to default value or this interferes with other tests
 
 	// back to normal state
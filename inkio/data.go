@@ -0,0 +1,70 @@
+// data holds the structured template data file readers for the ink application
+/*
+MIT License
+
+Copyright (c) 2017 Chris Simpkins
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package inkio
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// ReadDataFile reads a JSON, YAML, or TOML formatted key/value data file from the local file path or URL dataPath
+// and returns the top level document mapping as (map[string]interface{}, error).  The data format is determined by
+// the file extension (.json, .yaml, .yml, .toml)
+func ReadDataFile(dataPath string) (map[string]interface{}, error) {
+	dataText, readerr := ReadPathOrURL(dataPath)
+	if readerr != nil {
+		return nil, readerr
+	}
+
+	return ParseData(dataText, path.Ext(dataPath))
+}
+
+// ParseData parses the JSON, YAML, or TOML formatted dataText string to a key/value mapping.  The format is defined
+// by the file extension dataExt (e.g. ".json") and returns (map[string]interface{}, error)
+func ParseData(dataText string, dataExt string) (map[string]interface{}, error) {
+	data := make(map[string]interface{})
+	var parseerr error
+	switch strings.ToLower(dataExt) {
+	case ".json":
+		parseerr = json.Unmarshal([]byte(dataText), &data)
+	case ".yaml", ".yml":
+		parseerr = yaml.Unmarshal([]byte(dataText), &data)
+	case ".toml":
+		_, parseerr = toml.Decode(dataText, &data)
+	default:
+		return nil, fmt.Errorf("unsupported data file format '%s'. Use a .json, .yaml, .yml, or .toml file", dataExt)
+	}
+	if parseerr != nil {
+		return nil, parseerr
+	}
+
+	return data, nil
+}
@@ -0,0 +1,51 @@
+package inkio
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestReadDataFileFormats(t *testing.T) {
+	tests := []string{
+		filepath.Join("..", "testfiles", "data.json"),
+		filepath.Join("..", "testfiles", "data.yaml"),
+		filepath.Join("..", "testfiles", "data.toml"),
+	}
+
+	for _, dataPath := range tests {
+		data, err := ReadDataFile(dataPath)
+		if err != nil {
+			t.Errorf("[FAIL] ReadDataFile returned an unexpected error for %s: %v", dataPath, err)
+			continue
+		}
+		project, ok := data["project"].(map[string]interface{})
+		if !ok || project["name"] != "ink" {
+			t.Errorf("[FAIL] Expected ReadDataFile to return project.name = 'ink' for %s, received %v", dataPath, data["project"])
+		}
+		authors, ok := data["authors"].([]interface{})
+		if !ok || len(authors) != 2 || authors[1] != "饂饂" {
+			t.Errorf("[FAIL] Expected ReadDataFile to return authors = [Chris 饂饂] for %s, received %v", dataPath, data["authors"])
+		}
+	}
+}
+
+func TestReadDataFileMissingFile(t *testing.T) {
+	_, err := ReadDataFile(filepath.Join("..", "testfiles", "totallybogus.json"))
+	if err == nil {
+		t.Errorf("[FAIL] Expected ReadDataFile to return an error for a missing file, received nil")
+	}
+}
+
+func TestParseDataUnsupportedFormat(t *testing.T) {
+	_, err := ParseData("key=value", ".ini")
+	if err == nil {
+		t.Errorf("[FAIL] Expected ParseData to return an error for an unsupported data format, received nil")
+	}
+}
+
+func TestParseDataInvalidData(t *testing.T) {
+	_, err := ParseData("{ not json", ".json")
+	if err == nil {
+		t.Errorf("[FAIL] Expected ParseData to return an error for invalid JSON data, received nil")
+	}
+}
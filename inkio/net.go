@@ -4,9 +4,24 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"time"
 )
 
+// IsURL returns a boolean value for the presence of the http:// or https:// URL scheme on the templatePath parameter
+func IsURL(templatePath string) bool {
+	return strings.HasPrefix(templatePath, "http://") || strings.HasPrefix(templatePath, "https://")
+}
+
+// ReadPathOrURL reads a local file on the path templatePath or performs a GET request for templatePath when it is
+// a URL and returns (string, error)
+func ReadPathOrURL(templatePath string) (string, error) {
+	if IsURL(templatePath) {
+		return GetRequest(templatePath)
+	}
+	return ReadFileToString(templatePath)
+}
+
 // GetRequest performs a GET request for a templateURL url string with a 30 second timeout
 func GetRequest(templateURL string) (string, error) {
 	emptystring := "" // returned with errors
@@ -28,3 +28,21 @@ func TestGetRequestInvalidURL(t *testing.T) {
 		t.Errorf("[FAIL] GetRequest function should have returned 404 response status code on invalid URL to missing file")
 	}
 }
+
+func TestIsURL(t *testing.T) {
+	tests := []struct {
+		templatepath string
+		expected     bool
+	}{
+		{"https://raw.githubusercontent.com/chrissimpkins/ink/master/testfiles/template_1.txt.in", true},
+		{"http://test.com/template.txt.in", true},
+		{"testfiles/template_1.txt.in", false},
+		{"httpfiles/template_1.txt.in", false},
+	}
+
+	for _, testcase := range tests {
+		if IsURL(testcase.templatepath) != testcase.expected {
+			t.Errorf("[FAIL] Expected IsURL to return %t for '%s'", testcase.expected, testcase.templatepath)
+		}
+	}
+}
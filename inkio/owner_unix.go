@@ -0,0 +1,55 @@
+//go:build !windows
+// +build !windows
+
+// owner_unix holds the file ownership functions for the ink application on Unix platforms
+/*
+MIT License
+
+Copyright (c) 2017 Chris Simpkins
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package inkio
+
+import (
+	"os"
+	"syscall"
+)
+
+// copyOwner applies the user and group ownership of the file described by ownerInfo to the file on filePath.  The
+// ownership is not changed when it already matches, and permission errors (e.g. an unprivileged user that is not
+// permitted to change the file owner) are ignored
+func copyOwner(filePath string, ownerInfo os.FileInfo) error {
+	ownerStat, ok := ownerInfo.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+	fileInfo, staterr := os.Stat(filePath)
+	if staterr != nil {
+		return staterr
+	}
+	if fileStat, ok := fileInfo.Sys().(*syscall.Stat_t); ok && fileStat.Uid == ownerStat.Uid && fileStat.Gid == ownerStat.Gid {
+		return nil
+	}
+	if chownerr := os.Chown(filePath, int(ownerStat.Uid), int(ownerStat.Gid)); chownerr != nil && !os.IsPermission(chownerr) {
+		return chownerr
+	}
+	return nil
+}
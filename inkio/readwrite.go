@@ -26,8 +26,11 @@ SOFTWARE.
 package inkio
 
 import (
+	"bytes"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 // ReadFileToString reads text files from disk and returns (string, error)
@@ -44,20 +47,125 @@ func ReadFileToString(filepath string) (string, error) {
 // by the stdOutFlag boolean parameter value.  File writes occur on a path that is created from templatePath with the
 // `.in` file extension suffix removed from the file path
 func WriteString(templatePath string, stdOutFlag bool, renderedStringPointer *string) error {
+	return WriteStringToPath(OutFilePath(templatePath), stdOutFlag, renderedStringPointer)
+}
+
+// FileAttributes defines the file mode and ownership of rendered outfiles
+type FileAttributes struct {
+	Mode      os.FileMode // outfile permission bits, the existing outfile permissions are maintained when zero
+	OwnerFrom os.FileInfo // file info of the file that defines the outfile ownership, ownership is unchanged when nil
+}
+
+// WriteStringToPath writes a rendered string renderedStringPointer to the file path outPath or to the standard output
+// stream as determined by the stdOutFlag boolean parameter value.  Missing directories on outPath are created.  File
+// writes are atomic, an existing file on outPath is not modified unless the entire rendered string is written.  An
+// existing file with content that is identical to the rendered string is not rewritten
+func WriteStringToPath(outPath string, stdOutFlag bool, renderedStringPointer *string) error {
+	_, err := WriteStringToPathWithAttributes(outPath, stdOutFlag, renderedStringPointer, nil)
+	return err
+}
+
+// WriteStringToPathWithAttributes writes a rendered string renderedStringPointer to the file path outPath or to the
+// standard output stream as determined by the stdOutFlag boolean parameter value.  File writes apply the file mode
+// and ownership that are defined in attributes (nil = maintain the existing outfile attributes).  Returns false when
+// the existing file on outPath already contains the rendered string and the file write was skipped
+func WriteStringToPathWithAttributes(outPath string, stdOutFlag bool, renderedStringPointer *string, attributes *FileAttributes) (bool, error) {
 	if stdOutFlag {
 		os.Stdout.WriteString(*renderedStringPointer)
-	} else {
-		outPath := templatePath[0 : len(templatePath)-3]
-		f, err1 := os.Create(outPath)
-		defer f.Close()
-		if err1 != nil {
-			return err1
+		return true, nil
+	}
+	if direrr := os.MkdirAll(filepath.Dir(outPath), 0755); direrr != nil {
+		return false, direrr
+	}
+	return writeFileAtomic(outPath, []byte(*renderedStringPointer), attributes)
+}
+
+// writeFileAtomic writes data to a temporary file in the directory of outPath, syncs the temporary file to disk, and
+// renames it to outPath.  The permissions of an existing file on outPath are maintained unless attributes define a
+// file mode, new files are created with 0644 permissions.  Symbolic links on outPath are resolved so that the link
+// target file is replaced.  An existing file that already contains data is not rewritten so that the file
+// modification time is maintained, the file attributes are updated in place and false is returned
+func writeFileAtomic(outPath string, data []byte, attributes *FileAttributes) (bool, error) {
+	if linkTarget, linkerr := filepath.EvalSymlinks(outPath); linkerr == nil {
+		outPath = linkTarget
+	}
+	perm := os.FileMode(0644)
+	fileInfo, staterr := os.Stat(outPath)
+	if staterr == nil {
+		perm = fileInfo.Mode().Perm()
+	}
+	if attributes != nil && attributes.Mode != 0 {
+		perm = attributes.Mode.Perm()
+	}
+
+	if staterr == nil && fileInfo.Mode().IsRegular() && fileInfo.Size() == int64(len(data)) {
+		if existingData, readerr := ioutil.ReadFile(outPath); readerr == nil && bytes.Equal(existingData, data) {
+			if fileInfo.Mode().Perm() != perm {
+				if chmoderr := os.Chmod(outPath, perm); chmoderr != nil {
+					return false, chmoderr
+				}
+			}
+			if attributes != nil && attributes.OwnerFrom != nil {
+				if chownerr := copyOwner(outPath, attributes.OwnerFrom); chownerr != nil {
+					return false, chownerr
+				}
+			}
+			return false, nil
 		}
-		_, err2 := f.WriteString(*renderedStringPointer)
-		if err2 != nil {
-			return err2
+	}
+
+	f, createerr := ioutil.TempFile(filepath.Dir(outPath), "."+filepath.Base(outPath)+".ink-")
+	if createerr != nil {
+		return false, createerr
+	}
+	tempPath := f.Name()
+	if _, writeerr := f.Write(data); writeerr != nil {
+		f.Close()
+		os.Remove(tempPath)
+		return false, writeerr
+	}
+	if syncerr := f.Sync(); syncerr != nil {
+		f.Close()
+		os.Remove(tempPath)
+		return false, syncerr
+	}
+	if closeerr := f.Close(); closeerr != nil {
+		os.Remove(tempPath)
+		return false, closeerr
+	}
+	if chmoderr := os.Chmod(tempPath, perm); chmoderr != nil {
+		os.Remove(tempPath)
+		return false, chmoderr
+	}
+	if attributes != nil && attributes.OwnerFrom != nil {
+		if chownerr := copyOwner(tempPath, attributes.OwnerFrom); chownerr != nil {
+			os.Remove(tempPath)
+			return false, chownerr
 		}
-		f.Sync()
 	}
-	return nil
+	if renameerr := os.Rename(tempPath, outPath); renameerr != nil {
+		os.Remove(tempPath)
+		return false, renameerr
+	}
+	return true, nil
+}
+
+// CopyFile copies the file on srcPath to dstPath with the file mode and ownership of the source file.  The copy is
+// written atomically and an existing file on dstPath is replaced
+func CopyFile(srcPath string, dstPath string) error {
+	fileInfo, staterr := os.Stat(srcPath)
+	if staterr != nil {
+		return staterr
+	}
+	data, readerr := ioutil.ReadFile(srcPath)
+	if readerr != nil {
+		return readerr
+	}
+	_, writeerr := writeFileAtomic(dstPath, data, &FileAttributes{Mode: fileInfo.Mode().Perm(), OwnerFrom: fileInfo})
+	return writeerr
+}
+
+// OutFilePath returns the outfile path for the template path templatePath with the `.in` file extension suffix removed
+func OutFilePath(templatePath string) string {
+	return strings.TrimSuffix(templatePath, ".in")
 }
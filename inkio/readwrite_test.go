@@ -7,7 +7,9 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 )
 
 func TestReadFileToStringValidTextFile(t *testing.T) {
@@ -82,3 +84,171 @@ func TestWriteStringToFile(t *testing.T) {
 		t.Errorf("[FAIL] The expected file write for the TestWriteStringToFile test was not found. %v", fileerr)
 	}
 }
+
+func TestWriteStringToPathCreatesDirectories(t *testing.T) {
+	mockOutDir := "testing_outdir"
+	mockOutPath := filepath.Join(mockOutDir, "sub", "testing.txt")
+	teststring := "this is a test"
+	defer os.RemoveAll(mockOutDir)
+
+	writeerr := WriteStringToPath(mockOutPath, false, &teststring)
+	if writeerr != nil {
+		t.Errorf("[FAIL] There was an error with the file write for the TestWriteStringToPathCreatesDirectories test: %v", writeerr)
+	}
+	readstring, readerr := ioutil.ReadFile(mockOutPath)
+	if readerr != nil {
+		t.Errorf("[FAIL] Unable to read expected text file %s in TestWriteStringToPathCreatesDirectories test. %v", mockOutPath, readerr)
+	}
+	if string(readstring) != teststring {
+		t.Errorf("[FAIL] Expected to read '%s' from test file and actually read '%s'", teststring, readstring)
+	}
+}
+
+func TestCopyFile(t *testing.T) {
+	tempDir, tempErr := ioutil.TempDir("", "ink")
+	if tempErr != nil {
+		t.Fatalf("[FAIL] Unable to create temporary directory: %v", tempErr)
+	}
+	defer os.RemoveAll(tempDir)
+	srcPath := filepath.Join(tempDir, "testing.txt")
+	dstPath := filepath.Join(tempDir, "testing.txt.bak")
+	ioutil.WriteFile(srcPath, []byte("this is a test"), 0600)
+	ioutil.WriteFile(dstPath, []byte("old backup"), 0644)
+
+	if copyerr := CopyFile(srcPath, dstPath); copyerr != nil {
+		t.Errorf("[FAIL] CopyFile returned error value: %v", copyerr)
+	}
+	readstring, _ := ioutil.ReadFile(dstPath)
+	if string(readstring) != "this is a test" {
+		t.Errorf("[FAIL] Expected to read 'this is a test' from the copied file and actually read '%s'", readstring)
+	}
+	if fileInfo, _ := os.Stat(dstPath); runtime.GOOS != "windows" && fileInfo.Mode().Perm() != 0600 {
+		t.Errorf("[FAIL] Expected the copied file permissions 0600, received %v", fileInfo.Mode().Perm())
+	}
+	if copyerr := CopyFile(filepath.Join(tempDir, "bogus.txt"), dstPath); copyerr == nil {
+		t.Errorf("[FAIL] Expected CopyFile to return an error for a missing source file")
+	}
+}
+
+func TestOutFilePath(t *testing.T) {
+	if OutFilePath(filepath.Join("testing", "testing.txt.in")) != filepath.Join("testing", "testing.txt") {
+		t.Errorf("[FAIL] Expected OutFilePath to remove the .in file extension, received '%s'", OutFilePath(filepath.Join("testing", "testing.txt.in")))
+	}
+}
+
+func TestWriteStringToPathKeepsPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file permission bits are not supported on Windows")
+	}
+	tempDir, tempErr := ioutil.TempDir("", "ink")
+	if tempErr != nil {
+		t.Fatalf("[FAIL] Unable to create temporary directory: %v", tempErr)
+	}
+	defer os.RemoveAll(tempDir)
+	mockOutPath := filepath.Join(tempDir, "testing.txt")
+	ioutil.WriteFile(mockOutPath, []byte("old text"), 0600)
+
+	teststring := "this is a test"
+	writeerr := WriteStringToPath(mockOutPath, false, &teststring)
+	if writeerr != nil {
+		t.Errorf("[FAIL] There was an error with the file write for the TestWriteStringToPathKeepsPermissions test: %v", writeerr)
+	}
+	fileInfo, _ := os.Stat(mockOutPath)
+	if fileInfo.Mode().Perm() != 0600 {
+		t.Errorf("[FAIL] Expected file permissions 0600 to be maintained, received %v", fileInfo.Mode().Perm())
+	}
+	readstring, _ := ioutil.ReadFile(mockOutPath)
+	if string(readstring) != teststring {
+		t.Errorf("[FAIL] Expected to read '%s' from test file and actually read '%s'", teststring, readstring)
+	}
+	fileInfos, _ := ioutil.ReadDir(tempDir)
+	if len(fileInfos) != 1 {
+		t.Errorf("[FAIL] Expected temporary files to be removed after the write, found %d files", len(fileInfos))
+	}
+}
+
+func TestWriteStringToPathFailureKeepsExistingFile(t *testing.T) {
+	tempDir, tempErr := ioutil.TempDir("", "ink")
+	if tempErr != nil {
+		t.Fatalf("[FAIL] Unable to create temporary directory: %v", tempErr)
+	}
+	defer os.RemoveAll(tempDir)
+	// a directory on the outfile path cannot be replaced by the rendered file
+	mockOutPath := filepath.Join(tempDir, "testing.txt")
+	os.Mkdir(mockOutPath, 0755)
+	ioutil.WriteFile(filepath.Join(mockOutPath, "keep.txt"), []byte("old text"), 0644)
+
+	teststring := "this is a test"
+	writeerr := WriteStringToPath(mockOutPath, false, &teststring)
+	if writeerr == nil {
+		t.Errorf("[FAIL] Expected an error for a write over a directory path and the error value was 'nil'")
+	}
+	fileInfos, _ := ioutil.ReadDir(tempDir)
+	if len(fileInfos) != 1 {
+		t.Errorf("[FAIL] Expected temporary files to be removed after a failed write, found %d files", len(fileInfos))
+	}
+}
+
+func TestWriteStringToPathSkipsUnchangedFile(t *testing.T) {
+	tempDir, tempErr := ioutil.TempDir("", "ink")
+	if tempErr != nil {
+		t.Fatalf("[FAIL] Unable to create temporary directory: %v", tempErr)
+	}
+	defer os.RemoveAll(tempDir)
+	mockOutPath := filepath.Join(tempDir, "testing.txt")
+	teststring := "this is a test"
+	ioutil.WriteFile(mockOutPath, []byte(teststring), 0644)
+	mockModTime := time.Date(2017, 10, 18, 0, 0, 0, 0, time.UTC)
+	os.Chtimes(mockOutPath, mockModTime, mockModTime)
+
+	written, writeerr := WriteStringToPathWithAttributes(mockOutPath, false, &teststring, nil)
+	if writeerr != nil {
+		t.Errorf("[FAIL] There was an error with the file write for the TestWriteStringToPathSkipsUnchangedFile test: %v", writeerr)
+	}
+	if written {
+		t.Errorf("[FAIL] Expected the file write to be skipped for unchanged file content")
+	}
+	fileInfo, _ := os.Stat(mockOutPath)
+	if !fileInfo.ModTime().Equal(mockModTime) {
+		t.Errorf("[FAIL] Expected file modification time %v to be maintained, received %v", mockModTime, fileInfo.ModTime())
+	}
+
+	changedstring := "this is a changed test"
+	written, writeerr = WriteStringToPathWithAttributes(mockOutPath, false, &changedstring, nil)
+	if writeerr != nil {
+		t.Errorf("[FAIL] There was an error with the file write for the TestWriteStringToPathSkipsUnchangedFile test: %v", writeerr)
+	}
+	if !written {
+		t.Errorf("[FAIL] Expected the file to be written for changed file content")
+	}
+	readstring, _ := ioutil.ReadFile(mockOutPath)
+	if string(readstring) != changedstring {
+		t.Errorf("[FAIL] Expected to read '%s' from test file and actually read '%s'", changedstring, readstring)
+	}
+}
+
+func TestWriteStringToPathUnchangedFileAppliesMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file permission bits are not supported on Windows")
+	}
+	tempDir, tempErr := ioutil.TempDir("", "ink")
+	if tempErr != nil {
+		t.Fatalf("[FAIL] Unable to create temporary directory: %v", tempErr)
+	}
+	defer os.RemoveAll(tempDir)
+	mockOutPath := filepath.Join(tempDir, "testing.sh")
+	teststring := "echo test"
+	ioutil.WriteFile(mockOutPath, []byte(teststring), 0644)
+
+	written, writeerr := WriteStringToPathWithAttributes(mockOutPath, false, &teststring, &FileAttributes{Mode: 0755})
+	if writeerr != nil {
+		t.Errorf("[FAIL] There was an error with the file write for the TestWriteStringToPathUnchangedFileAppliesMode test: %v", writeerr)
+	}
+	if written {
+		t.Errorf("[FAIL] Expected the file write to be skipped for unchanged file content")
+	}
+	fileInfo, _ := os.Stat(mockOutPath)
+	if fileInfo.Mode().Perm() != 0755 {
+		t.Errorf("[FAIL] Expected file permissions 0755 to be applied to the unchanged file, received %v", fileInfo.Mode().Perm())
+	}
+}
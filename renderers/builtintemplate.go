@@ -28,6 +28,11 @@ package renderers
 import (
 	"bytes"
 	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
 	"text/template"
 
 	"github.com/chrissimpkins/ink/inkio"
@@ -43,83 +48,263 @@ type ReplacementStrings struct {
 //  the {{ ink }} template tag as a built in value
 var InkmarkReplaceString = ""
 
+// NumberedReplaceStrings is a global variable that holds the user defined replacement strings for the {{ .Two }}
+// through {{ .Ten }} template tags, keyed by the ReplacementStrings field name (e.g. "Two").  Tags that do not
+// have a defined replacement string are rendered as their own tag text
+var NumberedReplaceStrings = map[string]string{}
+
+// TemplateData is a global variable that holds user defined key/value data (e.g. from a --data file) that are made
+// available to builtin templates as template data.  When defined, the ReplacementStrings field values remain
+// available on their field names (e.g. {{ .Ink }}) and take precedence over keys with the same name
+var TemplateData map[string]interface{}
+
+// EnvPrefix is a global variable that holds the user defined environment variable name prefix.  When defined, the
+// environment variables with names that begin with the prefix are made available to builtin templates on the .Env
+// template data key with the prefix removed from the variable names (e.g. INK_VERSION = {{ .Env.VERSION }})
+var EnvPrefix = ""
+
+// StrictEnv is a global variable that determines whether the use of an undefined environment variable with the
+// {{ env "NAME" }} template function fails the render (true) or renders an empty string (false)
+var StrictEnv = false
+
+// Strict is a global variable that determines whether renders fail on template text that is not replaced.  Builtin
+// template renders fail on references to missing template data keys and on template tokens that remain in the
+// rendered text (e.g. an undefined {{ .Two }} tag or a {{ .Tow }} typo).  User template renders fail when the
+// --find= definition does not match the template text
+var Strict = false
+
+// LeftDelim is a global variable that holds the left action delimiter of builtin templates.  User defined delimiters
+// (e.g. [[ and ]]) prevent collisions with template syntax of other applications in the template text
+var LeftDelim = "{{"
+
+// RightDelim is a global variable that holds the right action delimiter of builtin templates
+var RightDelim = "}}"
+
+// ParseDelims parses a comma separated left and right delimiter definition (e.g. "[[,]]") and returns the left and
+// right delimiters
+func ParseDelims(delims string) (string, string, error) {
+	delimSlice := strings.Split(delims, ",")
+	if len(delimSlice) != 2 {
+		return "", "", fmt.Errorf("delimiters '%s' must be defined as a comma separated left and right delimiter pair (e.g. [[,]])", delims)
+	}
+	left, right := strings.TrimSpace(delimSlice[0]), strings.TrimSpace(delimSlice[1])
+	if len(left) == 0 || len(right) == 0 {
+		return "", "", fmt.Errorf("delimiters '%s' must define non-empty left and right delimiters", delims)
+	}
+	return left, right, nil
+}
+
 // RenderFromLocalInkTemplate is a function that renders a text template on path templatePath with a user specified
 // replacement string replaceStringPointer (pointer to string) and returns pointer to rendered string and error
 func RenderFromLocalInkTemplate(templatePath string, replaceStringPointer *string) (*string, error) {
+	renderedStringPointer, _, rendererr := RenderFromLocalInkTemplateWithFrontMatter(templatePath, replaceStringPointer)
+	return renderedStringPointer, rendererr
+}
+
+// RenderFromLocalInkTemplateWithFrontMatter is a function that renders a text template on path templatePath with a
+// user specified replacement string replaceStringPointer (pointer to string) and returns pointer to rendered string,
+// the template front matter (nil when the template does not have a front matter block) and error
+func RenderFromLocalInkTemplateWithFrontMatter(templatePath string, replaceStringPointer *string) (*string, *FrontMatter, error) {
 	templateText, readerr := inkio.ReadFileToString(templatePath)
 	emptystring := "" // returned with errors
 
 	if readerr != nil {
 		responseReadErr := fmt.Errorf("unable to read local template file '%s'. %v", templatePath, readerr)
-		return &emptystring, responseReadErr
+		return &emptystring, nil, responseReadErr
 	}
 
-	renderedStringPointer, rendererr := renderInkTemplate(&templateText, replaceStringPointer)
+	renderedStringPointer, frontMatter, rendererr := renderInkTemplate(filepath.Clean(templatePath), &templateText, replaceStringPointer)
 
 	if rendererr != nil {
 		templateRenderErr := fmt.Errorf("unable to render local template file '%s'. %v", templatePath, rendererr)
-		return &emptystring, templateRenderErr
+		return &emptystring, nil, templateRenderErr
 	}
 
-	return renderedStringPointer, rendererr
+	return renderedStringPointer, frontMatter, rendererr
 }
 
 // RenderFromRemoteInkTemplate is a function that renders a text template at URL templateURL with a user specified
 // replacement string replaceStringPointer (pointer to string) and returns pointer to rendered string and error
 func RenderFromRemoteInkTemplate(templateURL string, replaceStringPointer *string) (*string, error) {
+	renderedStringPointer, _, rendererr := RenderFromRemoteInkTemplateWithFrontMatter(templateURL, replaceStringPointer)
+	return renderedStringPointer, rendererr
+}
+
+// RenderFromRemoteInkTemplateWithFrontMatter is a function that renders a text template at URL templateURL with a
+// user specified replacement string replaceStringPointer (pointer to string) and returns pointer to rendered string,
+// the template front matter (nil when the template does not have a front matter block) and error
+func RenderFromRemoteInkTemplateWithFrontMatter(templateURL string, replaceStringPointer *string) (*string, *FrontMatter, error) {
 	templateText, geterr := inkio.GetRequest(templateURL)
 	emptystring := "" //returned with errors
 
 	if geterr != nil {
 		responseGetErr := fmt.Errorf("unable to perform GET request for remote template file '%s'. %v", templateURL, geterr)
-		return &emptystring, responseGetErr
+		return &emptystring, nil, responseGetErr
 	}
 
-	renderedStringPointer, rendererr := renderInkTemplate(&templateText, replaceStringPointer)
+	renderedStringPointer, frontMatter, rendererr := renderInkTemplate(templateURL, &templateText, replaceStringPointer)
 
 	if rendererr != nil {
 		templateRenderErr := fmt.Errorf("unable to render remote template pulled by GET request from '%s'. %v", templateURL, rendererr)
-		return &emptystring, templateRenderErr
+		return &emptystring, nil, templateRenderErr
 	}
 
-	return renderedStringPointer, rendererr
+	return renderedStringPointer, frontMatter, rendererr
+}
+
+// renderSettings holds the template parse and execution settings of a builtin template render and its includes
+type renderSettings struct {
+	leftDelim  string
+	rightDelim string
+	strict     bool // fail the render on references to missing template data keys
 }
 
-// renderTemplate handles renders of the template text replacements for local and remote template files and returns
-// a pointer to the rendered template string + error
-func renderInkTemplate(templateText *string, replaceString *string) (*string, error) {
+// renderInkTemplate handles renders of the template text replacements for local and remote template files and returns
+// a pointer to the rendered template string + template front matter + error.  templateSource is the local file path or
+// URL of the template and is used to resolve {{ include "path" }} template paths
+func renderInkTemplate(templateSource string, templateText *string, replaceString *string) (*string, *FrontMatter, error) {
 	// set global variable with replacement string variable (used to support `{{ ink }}` template tags via ink() function below)
 	InkmarkReplaceString = *replaceString
 	emptystring := ""
-	funcs := template.FuncMap{"ink": ink}
-	t, err := template.New("ink").Funcs(funcs).Parse(*templateText)
 
+	frontMatter, bodyText, _, frontmattererr := SplitFrontMatter(*templateText)
+	if frontmattererr != nil {
+		return &emptystring, nil, frontmattererr
+	}
+	settings := renderSettings{leftDelim: LeftDelim, rightDelim: RightDelim, strict: Strict}
+	var defaultData map[string]interface{}
+	if frontMatter != nil {
+		if len(frontMatter.LeftDelim) > 0 {
+			settings.leftDelim, settings.rightDelim = frontMatter.LeftDelim, frontMatter.RightDelim
+		}
+		settings.strict = settings.strict || frontMatter.Strict
+		defaultData = frontMatter.Data
+	}
+
+	r := ReplacementStrings{One: *replaceString, Ink: *replaceString}
+	numberedFields := []struct {
+		name  string
+		field *string
+	}{
+		{"Two", &r.Two},
+		{"Three", &r.Three},
+		{"Four", &r.Four},
+		{"Five", &r.Five},
+		{"Six", &r.Six},
+		{"Seven", &r.Seven},
+		{"Eight", &r.Eight},
+		{"Nine", &r.Nine},
+		{"Ten", &r.Ten},
+	}
+	for _, numbered := range numberedFields {
+		if numberedReplaceString, ok := NumberedReplaceStrings[numbered.name]; ok {
+			*numbered.field = numberedReplaceString
+		} else {
+			*numbered.field = settings.leftDelim + "." + numbered.name + settings.rightDelim // render undefined tags as themselves
+		}
+	}
+
+	renderedString, err := executeInkTemplate(templateSource, "ink", bodyText, templateData(r, defaultData), settings, []string{templateSource})
 	if err != nil {
-		return &emptystring, err
-	}
-
-	r := ReplacementStrings{
-		*replaceString,
-		"{{.One}}",
-		"{{.Two}}",
-		"{{.Three}}",
-		"{{.Four}}",
-		"{{.Five}}",
-		"{{.Six}}",
-		"{{.Seven}}",
-		"{{.Eight}}",
-		"{{.Nine}}",
-		*replaceString,
+		return &emptystring, nil, err
+	}
+	if settings.strict {
+		if tokenerr := unresolvedTokens(renderedString, settings); tokenerr != nil {
+			return &emptystring, nil, tokenerr
+		}
 	}
+
+	return &renderedString, frontMatter, nil
+}
+
+// executeInkTemplate parses the template text templateText from templateSource with the template name name and
+// executes it with data.  includeChain holds the sources of the template and all including templates
+func executeInkTemplate(templateSource string, name string, templateText string, data interface{}, settings renderSettings, includeChain []string) (string, error) {
+	funcs := TemplateFuncs()
+	funcs["include"] = func(includeName string, includeData ...interface{}) (string, error) {
+		return includeTemplate(templateSource, includeName, data, includeData, settings, includeChain)
+	}
+	t := template.New(name).Delims(settings.leftDelim, settings.rightDelim).Funcs(funcs)
+	if settings.strict {
+		t = t.Option("missingkey=error")
+	}
+	t, err := t.Parse(templateText)
+	if err != nil {
+		return "", err
+	}
+
 	buf := new(bytes.Buffer)
-	executeerr := t.Execute(buf, r)
+	executeerr := t.Execute(buf, data)
 	if executeerr != nil {
-		return &emptystring, executeerr
+		return "", executeerr
 	}
 
-	renderedString := buf.String()
-	return &renderedString, nil
+	return buf.String(), nil
+}
+
+// unresolvedTokens returns an error when the rendered text renderedText includes template tokens with the render
+// settings delimiters, such as undefined {{ .Two }} ... {{ .Ten }} tags that are rendered as their own tag text
+func unresolvedTokens(renderedText string, settings renderSettings) error {
+	tokenRegex := regexp.MustCompile(regexp.QuoteMeta(settings.leftDelim) + `-?\s*(?:ink|\.[A-Za-z_]\w*(?:\.[A-Za-z_]\w*)*)\s*-?` + regexp.QuoteMeta(settings.rightDelim))
+	tokenIndices := tokenRegex.FindAllStringIndex(renderedText, -1)
+	if len(tokenIndices) == 0 {
+		return nil
+	}
+	first := tokenIndices[0]
+	line := strings.Count(renderedText[:first[0]], "\n") + 1
+	return fmt.Errorf("the rendered text includes %d unresolved template token(s), the first is '%s' on line %d", len(tokenIndices), renderedText[first[0]:first[1]], line)
+}
+
+// templateData returns the data that are passed to builtin template renders.  This is the ReplacementStrings struct
+// when user defined TemplateData, EnvPrefix, and front matter defaultData are not available, otherwise a mapping of
+// the defaultData and TemplateData keys that includes the .Env environment variables and the ReplacementStrings field
+// values.  TemplateData values take precedence over defaultData values with the same top level key
+func templateData(r ReplacementStrings, defaultData map[string]interface{}) interface{} {
+	if TemplateData == nil && len(EnvPrefix) == 0 && defaultData == nil {
+		return r
+	}
+
+	data := make(map[string]interface{}, len(defaultData)+len(TemplateData)+12)
+	for key, value := range defaultData {
+		data[key] = value
+	}
+	for key, value := range TemplateData {
+		data[key] = value
+	}
+	if len(EnvPrefix) > 0 {
+		data["Env"] = prefixedEnv(EnvPrefix)
+	}
+	rValue := reflect.ValueOf(r)
+	for i := 0; i < rValue.NumField(); i++ {
+		data[rValue.Type().Field(i).Name] = rValue.Field(i).String()
+	}
+
+	return data
+}
+
+// prefixedEnv returns a mapping of the environment variables with names that begin with prefix.  The prefix is
+// removed from the variable names in the mapping keys
+func prefixedEnv(prefix string) map[string]string {
+	envVariables := make(map[string]string)
+	for _, envVariable := range os.Environ() {
+		keyValue := strings.SplitN(envVariable, "=", 2)
+		if len(keyValue) == 2 && strings.HasPrefix(keyValue[0], prefix) {
+			envVariables[strings.TrimPrefix(keyValue[0], prefix)] = keyValue[1]
+		}
+	}
+
+	return envVariables
 }
 
 // ink function supports use of the `ink` template tag as part of the builtin templating
 func ink() string { return InkmarkReplaceString }
+
+// env function supports use of the `env "NAME"` template function to render environment variable values as part of
+// the builtin templating.  Undefined environment variables return an error when StrictEnv is true
+func env(name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok && StrictEnv {
+		return "", fmt.Errorf("environment variable '%s' is not defined", name)
+	}
+	return value, nil
+}
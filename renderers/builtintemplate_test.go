@@ -1,6 +1,7 @@
 package renderers
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 )
@@ -59,6 +60,137 @@ func TestRenderBuiltinUppercaseInkTagLocal(t *testing.T) {
 	}
 }
 
+func TestRenderBuiltinNumberedTagsLocal(t *testing.T) {
+	tests := []struct {
+		numbered    map[string]string
+		replacement string
+		expected    string
+	}{
+		{map[string]string{"Two": "2017-10-18", "Three": "abcd123"}, "0.8.0", "version=0.8.0 date=2017-10-18 sha=abcd123 next={{.Four}}"},
+		{map[string]string{"Two": "åß∂ƒç√∫", "Four": "饂饂饂饂"}, "0.8.0", "version=0.8.0 date=åß∂ƒç√∫ sha={{.Three}} next=饂饂饂饂"},
+		{map[string]string{}, "0.8.0", "version=0.8.0 date={{.Two}} sha={{.Three}} next={{.Four}}"},
+	}
+
+	for _, testcase := range tests {
+		NumberedReplaceStrings = testcase.numbered
+		haystack, err := RenderFromLocalInkTemplate(filepath.Join("..", "testfiles", "template_numbered.txt.in"), &testcase.replacement)
+		NumberedReplaceStrings = map[string]string{} // reset to default value or this interferes with other tests
+		if err != nil {
+			t.Errorf("[FAIL] RenderFromInkTemplate execution returned error value: %v", err)
+		}
+		if *haystack != testcase.expected {
+			t.Errorf("[FAIL] Expected rendered template value = '%s' and received rendered template value '%s'", testcase.expected, *haystack)
+		}
+	}
+}
+
+func TestRenderBuiltinTemplateDataLocal(t *testing.T) {
+	TemplateData = map[string]interface{}{
+		"project": map[string]interface{}{"name": "ink", "version": "0.8.0"},
+		"authors": []interface{}{"Chris", "饂饂"},
+	}
+	defer func() { TemplateData = nil }() // reset to default value or this interferes with other tests
+
+	replacement := "abcd123"
+	expected := "name=ink version=0.8.0 authors=Chris 饂饂 sha=abcd123"
+	haystack, err := RenderFromLocalInkTemplate(filepath.Join("..", "testfiles", "template_data.txt.in"), &replacement)
+	if err != nil {
+		t.Errorf("[FAIL] RenderFromInkTemplate execution returned error value: %v", err)
+	}
+	if *haystack != expected {
+		t.Errorf("[FAIL] Expected rendered template value = '%s' and received rendered template value '%s'", expected, *haystack)
+	}
+
+	// ReplacementStrings fields remain available with template data
+	expected = "sha=abcd123 test=abcd123"
+	haystack, err = RenderFromLocalInkTemplate(filepath.Join("..", "testfiles", "template_2.txt.in"), &replacement)
+	if err != nil {
+		t.Errorf("[FAIL] RenderFromInkTemplate execution returned error value: %v", err)
+	}
+	if *haystack != expected {
+		t.Errorf("[FAIL] Expected rendered template value = '%s' and received rendered template value '%s'", expected, *haystack)
+	}
+}
+
+func TestRenderBuiltinEnvLocal(t *testing.T) {
+	os.Setenv("INK_TEST_VERSION", "0.8.0")
+	os.Setenv("INK_TEST_SHA", "饂饂饂饂")
+	EnvPrefix = "INK_"
+	defer func() {
+		// reset to default values or this interferes with other tests
+		os.Unsetenv("INK_TEST_VERSION")
+		os.Unsetenv("INK_TEST_SHA")
+		EnvPrefix = ""
+	}()
+
+	replacement := "abcd123"
+	expected := "version=0.8.0 sha=饂饂饂饂"
+	haystack, err := RenderFromLocalInkTemplate(filepath.Join("..", "testfiles", "template_env.txt.in"), &replacement)
+	if err != nil {
+		t.Errorf("[FAIL] RenderFromInkTemplate execution returned error value: %v", err)
+	}
+	if *haystack != expected {
+		t.Errorf("[FAIL] Expected rendered template value = '%s' and received rendered template value '%s'", expected, *haystack)
+	}
+}
+
+func TestRenderBuiltinStrictEnvMissingVariableRaisesError(t *testing.T) {
+	os.Unsetenv("INK_TEST_VERSION")
+	EnvPrefix = "INK_"
+	StrictEnv = true
+	defer func() {
+		// reset to default values or this interferes with other tests
+		EnvPrefix = ""
+		StrictEnv = false
+	}()
+
+	replacement := "abcd123"
+	_, err := RenderFromLocalInkTemplate(filepath.Join("..", "testfiles", "template_env.txt.in"), &replacement)
+	if err == nil {
+		t.Errorf("[FAIL] Expected error to be raised for an undefined environment variable in strict mode and the error value was 'nil'")
+	}
+}
+
+func TestRenderBuiltinDelimsLocal(t *testing.T) {
+	LeftDelim, RightDelim = "[[", "]]"
+	defer func() { LeftDelim, RightDelim = "{{", "}}" }() // reset to default values or this interferes with other tests
+
+	replacement := "abcd123"
+	expected := "image: abcd123\nname: {{ .Values.name }}\ntag: [[.Two]] row=ABCD123"
+	haystack, err := RenderFromLocalInkTemplate(filepath.Join("..", "testfiles", "include", "template_delims.txt.in"), &replacement)
+	if err != nil {
+		t.Errorf("[FAIL] RenderFromInkTemplate execution returned error value: %v", err)
+	}
+	if *haystack != expected {
+		t.Errorf("[FAIL] Expected rendered template value = '%s' and received rendered template value '%s'", expected, *haystack)
+	}
+}
+
+func TestParseDelims(t *testing.T) {
+	tests := []struct {
+		delims   string
+		left     string
+		right    string
+		errFound bool
+	}{
+		{"[[,]]", "[[", "]]", false},
+		{"<%, %>", "<%", "%>", false},
+		{"[[", "", "", true},
+		{"[[,]],", "", "", true},
+		{",]]", "", "", true},
+	}
+
+	for _, testcase := range tests {
+		left, right, err := ParseDelims(testcase.delims)
+		if (err != nil) != testcase.errFound {
+			t.Errorf("[FAIL] Expected error found = %t for delimiters '%s', received error value: %v", testcase.errFound, testcase.delims, err)
+		}
+		if left != testcase.left || right != testcase.right {
+			t.Errorf("[FAIL] Expected delimiters '%s' and '%s' for '%s', received '%s' and '%s'", testcase.left, testcase.right, testcase.delims, left, right)
+		}
+	}
+}
+
 func TestRenderBuiltinBadLocalFilePathRaisesError(t *testing.T) {
 	replacestring := "testing"
 	_, err := RenderFromLocalInkTemplate("completelybogus.txt.in", &replacestring)
@@ -67,6 +199,140 @@ func TestRenderBuiltinBadLocalFilePathRaisesError(t *testing.T) {
 	}
 }
 
+func TestRenderBuiltinFrontMatterLocal(t *testing.T) {
+	replacement := "abcd123"
+	expected := "name: frontmatter\nversion: 1.0\nink: abcd123 {{ ink }}"
+	haystack, frontMatter, err := RenderFromLocalInkTemplateWithFrontMatter(filepath.Join("..", "testfiles", "template_frontmatter.txt.in"), &replacement)
+	if err != nil {
+		t.Errorf("[FAIL] RenderFromLocalInkTemplateWithFrontMatter execution returned error value: %v", err)
+	}
+	if *haystack != expected {
+		t.Errorf("[FAIL] Expected rendered template value = '%s' and received rendered template value '%s'", expected, *haystack)
+	}
+	if frontMatter == nil || !frontMatter.Strict || frontMatter.LeftDelim != "[[" || frontMatter.RightDelim != "]]" {
+		t.Errorf("[FAIL] Unexpected front matter: %+v", frontMatter)
+	}
+}
+
+func TestRenderBuiltinFrontMatterDataPrecedence(t *testing.T) {
+	TemplateData = map[string]interface{}{"name": "datafile"}
+	defer func() { TemplateData = nil }() // reset to default value or this interferes with other tests
+
+	replacement := "abcd123"
+	expected := "name: datafile\nversion: 1.0\nink: abcd123 {{ ink }}"
+	haystack, err := RenderFromLocalInkTemplate(filepath.Join("..", "testfiles", "template_frontmatter.txt.in"), &replacement)
+	if err != nil {
+		t.Errorf("[FAIL] RenderFromLocalInkTemplate execution returned error value: %v", err)
+	}
+	if *haystack != expected {
+		t.Errorf("[FAIL] Expected rendered template value = '%s' and received rendered template value '%s'", expected, *haystack)
+	}
+}
+
+func TestRenderBuiltinStrict(t *testing.T) {
+	Strict = true
+	defer func() { Strict = false }() // reset to default value or this interferes with other tests
+
+	replacement := "abcd123"
+	tests := []struct {
+		templateText string
+		data         map[string]interface{}
+		expectError  bool
+	}{
+		{"{{ ink }} {{ .One }}", nil, false},
+		{"{{ ink }} {{ .Two }}", nil, true},                                   // undefined numbered tag rendered as itself
+		{"{{ .name }}", map[string]interface{}{"name": "app"}, false},         // defined data key
+		{"{{ .nmae }}", map[string]interface{}{"name": "app"}, true},          // missing data key
+		{"{{ .name }}", map[string]interface{}{"name": "{{ .Tow }}"}, true},   // token in a template data value
+		{"{{ .name }}", map[string]interface{}{"name": "{{ range }}"}, false}, // text that is not a template token
+		{"---\nstrict: false\n---\n{{ .Three }}", nil, true},                  // front matter does not disable --strict
+		{"---\ndelims: \"[[,]]\"\n---\n{{ .Two }} [[ ink ]]", nil, false},     // tokens with other delimiters
+		{"---\ndelims: \"[[,]]\"\n---\n{{ .Two }} [[ .Two ]]", nil, true},     // tokens with the template delimiters
+	}
+	for _, test := range tests {
+		TemplateData = test.data
+		_, _, err := renderInkTemplate("strict.txt.in", &test.templateText, &replacement)
+		TemplateData = nil
+		if test.expectError && err == nil {
+			t.Errorf("[FAIL] Expected strict mode render error for template '%s'", test.templateText)
+		} else if !test.expectError && err != nil {
+			t.Errorf("[FAIL] Unexpected strict mode render error for template '%s': %v", test.templateText, err)
+		}
+	}
+}
+
+func TestRenderBuiltinFrontMatterStrict(t *testing.T) {
+	replacement := "abcd123"
+	templateText := "---\nstrict: true\n---\n{{ .missing }}"
+	_, _, err := renderInkTemplate("strict.txt.in", &templateText, &replacement)
+	if err == nil {
+		t.Errorf("[FAIL] Expected error for missing template data key in strict front matter template")
+	}
+
+	templateText = "---\nstrict: false\n---\n{{ ink }}"
+	haystack, _, err := renderInkTemplate("lenient.txt.in", &templateText, &replacement)
+	if err != nil || *haystack != "abcd123" {
+		t.Errorf("[FAIL] Expected rendered template value 'abcd123' and received '%s' (error %v)", *haystack, err)
+	}
+}
+
+func TestSplitFrontMatter(t *testing.T) {
+	cases := []struct {
+		text   string
+		body   string
+		lines  int
+		output string
+		mode   os.FileMode
+		isNil  bool
+	}{
+		{"---\noutput: out.txt\nmode: 0755\n---\nbody", "body", 4, "out.txt", 0755, false},
+		{"---\noutput: ./deploy/../out.txt\n---\nbody", "body", 3, "out.txt", 0, false},
+		{"---\noutput = \"out.txt\"\nmode = \"0600\"\n---\nbody\n", "body\n", 4, "out.txt", 0600, false},
+		{"+++\noutput = \"out.txt\"\n+++\r\nbody", "body", 3, "out.txt", 0, false},
+		{"---\nname: app\n---\nbody", "---\nname: app\n---\nbody", 0, "", 0, true},
+		{"---\nname: app\n", "---\nname: app\n", 0, "", 0, true},
+		{"---\n---\nbody", "---\n---\nbody", 0, "", 0, true},
+		{"body\n---\noutput: out.txt\n---\n", "body\n---\noutput: out.txt\n---\n", 0, "", 0, true},
+	}
+	for _, c := range cases {
+		frontMatter, body, lines, err := SplitFrontMatter(c.text)
+		if err != nil {
+			t.Errorf("[FAIL] SplitFrontMatter(%q) returned error value: %v", c.text, err)
+			continue
+		}
+		if body != c.body || lines != c.lines {
+			t.Errorf("[FAIL] SplitFrontMatter(%q) expected body %q and %d lines, received body %q and %d lines", c.text, c.body, c.lines, body, lines)
+		}
+		if c.isNil {
+			if frontMatter != nil {
+				t.Errorf("[FAIL] SplitFrontMatter(%q) expected nil front matter, received %+v", c.text, frontMatter)
+			}
+			continue
+		}
+		if frontMatter == nil || frontMatter.Output != c.output || frontMatter.Mode != c.mode {
+			t.Errorf("[FAIL] SplitFrontMatter(%q) unexpected front matter: %+v", c.text, frontMatter)
+		}
+	}
+
+	invalid := []string{
+		"---\nmode: 0999\n---\n",
+		"---\nmode: true\n---\n",
+		"---\ndelims: \"[[\"\n---\n",
+		"---\nstrict: yes please\n---\n",
+		"---\ndata: value\n---\n",
+		"---\noutput: 1\n---\n",
+		"---\noutput: /tmp/escaped.txt\n---\n",
+		"---\noutput: ../escaped.txt\n---\n",
+		"---\noutput: sub/../../../escaped.txt\n---\n",
+		"---\noutput: ./\n---\n",
+	}
+	for _, text := range invalid {
+		if _, _, _, err := SplitFrontMatter(text); err == nil {
+			t.Errorf("[FAIL] Expected SplitFrontMatter(%q) to return an error", text)
+		}
+	}
+}
+
 /*
 REMOTE TEMPLATE TESTS
 */
@@ -0,0 +1,163 @@
+// frontmatter holds the builtin template front matter functions for the ink application
+/*
+MIT License
+
+Copyright (c) 2017 Chris Simpkins
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package renderers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chrissimpkins/ink/inkio"
+)
+
+// FrontMatter holds the rendering settings that are defined in an optional front matter block at the beginning of a
+// builtin template.  Zero values are settings that the front matter does not define
+type FrontMatter struct {
+	Data       map[string]interface{} // default template data values, --data file and command line values take precedence
+	LeftDelim  string                 // builtin template delimiters
+	RightDelim string
+	Output     string      // outfile path, relative to and below the default outfile directory
+	Mode       os.FileMode // outfile permission bits
+	Strict     bool        // fail renders on references to missing template data keys
+}
+
+// frontMatterKeys are the supported front matter block keys
+var frontMatterKeys = map[string]bool{"data": true, "delims": true, "output": true, "mode": true, "strict": true}
+
+// SplitFrontMatter separates an optional front matter block from the beginning of the builtin template text
+// templateText.  The block is YAML or TOML formatted text between "---" lines, or TOML formatted text between "+++"
+// lines, with the front matter keys data, delims, output, mode, and strict.  Blocks that are not mappings of front
+// matter keys (e.g. a YAML document start marker in a YAML file template) are template text.  Returns the front
+// matter (nil without a front matter block), the template text that follows the block, and the number of lines in
+// the block
+func SplitFrontMatter(templateText string) (*FrontMatter, string, int, error) {
+	lines := strings.SplitAfter(templateText, "\n")
+	fence := strings.TrimRight(lines[0], "\r\n")
+	if fence != "---" && fence != "+++" {
+		return nil, templateText, 0, nil
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimRight(lines[i], "\r\n") != fence {
+			continue
+		}
+		blockText := strings.Join(lines[1:i], "")
+		block, parseerr := inkio.ParseData(blockText, ".toml")
+		if fence == "---" {
+			if yamlBlock, yamlerr := inkio.ParseData(blockText, ".yaml"); yamlerr == nil {
+				block, parseerr = yamlBlock, nil
+			}
+		}
+		if parseerr != nil || len(block) == 0 {
+			return nil, templateText, 0, nil
+		}
+		for key := range block {
+			if !frontMatterKeys[key] {
+				return nil, templateText, 0, nil
+			}
+		}
+		frontMatter, frontmattererr := newFrontMatter(block)
+		if frontmattererr != nil {
+			return nil, templateText, 0, fmt.Errorf("invalid template front matter. %v", frontmattererr)
+		}
+		return frontMatter, strings.Join(lines[i+1:], ""), i + 1, nil
+	}
+	return nil, templateText, 0, nil
+}
+
+// newFrontMatter returns the FrontMatter for the parsed front matter block mapping block
+func newFrontMatter(block map[string]interface{}) (*FrontMatter, error) {
+	frontMatter := &FrontMatter{}
+	keys := make([]string, 0, len(block))
+	for key := range block {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys) // report the first invalid value in a stable order
+
+	for _, key := range keys {
+		value := block[key]
+		switch key {
+		case "data":
+			data, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("'data' must be a mapping of template data keys and values")
+			}
+			frontMatter.Data = data
+		case "delims":
+			var delims string
+			switch v := value.(type) {
+			case string:
+				delims = v
+			case []interface{}:
+				if len(v) == 2 {
+					delims = fmt.Sprintf("%v,%v", v[0], v[1])
+				}
+			}
+			left, right, delimserr := ParseDelims(delims)
+			if delimserr != nil {
+				return nil, fmt.Errorf("'delims' must be a \"left,right\" string or a list of the left and right delimiters. %v", delimserr)
+			}
+			frontMatter.LeftDelim, frontMatter.RightDelim = left, right
+		case "output":
+			output, ok := value.(string)
+			if !ok || len(output) == 0 {
+				return nil, fmt.Errorf("'output' must be a file path")
+			}
+			// templates must not write outside of the default outfile directory (or the --outdir directory)
+			output = filepath.Clean(filepath.FromSlash(output))
+			if filepath.IsAbs(output) || len(filepath.VolumeName(output)) > 0 || strings.HasPrefix(output, string(filepath.Separator)) || output == "." || output == ".." || strings.HasPrefix(output, ".."+string(filepath.Separator)) {
+				return nil, fmt.Errorf("'output' must be a relative file path below the default outfile directory")
+			}
+			frontMatter.Output = output
+		case "mode":
+			var mode uint64
+			var modeerr error
+			switch v := value.(type) {
+			case string:
+				mode, modeerr = strconv.ParseUint(v, 8, 32)
+			case int:
+				mode = uint64(v)
+			case int64:
+				mode = uint64(v)
+			default:
+				modeerr = fmt.Errorf("unsupported type")
+			}
+			if modeerr != nil || mode == 0 || mode > 0777 {
+				return nil, fmt.Errorf("'mode' must be an octal file mode (e.g. \"0755\")")
+			}
+			frontMatter.Mode = os.FileMode(mode)
+		case "strict":
+			strict, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("'strict' must be true or false")
+			}
+			frontMatter.Strict = strict
+		}
+	}
+	return frontMatter, nil
+}
@@ -0,0 +1,489 @@
+// funcs holds the builtin template function registry for the ink application
+/*
+MIT License
+
+Copyright (c) 2017 Chris Simpkins
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package renderers
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+)
+
+// TemplateFuncs returns the registry of functions that are available in builtin templates.  This registry is shared
+// by the builtin template renderer and the template linter so that linting remains in step with rendering
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		// ink
+		"ink":     ink,
+		"env":     env,
+		"include": includeUnavailable,
+
+		// strings
+		"trim":       strings.TrimSpace,
+		"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+		"replace":    func(old, new, s string) string { return strings.Replace(s, old, new, -1) },
+		"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+		"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+		"repeat":     func(count int, s string) string { return strings.Repeat(s, count) },
+		"split":      func(sep, s string) []string { return strings.Split(s, sep) },
+		"join":       join,
+		"indent":     indent,
+		"trunc":      trunc,
+		"quote":      func(s interface{}) string { return strconv.Quote(toString(s)) },
+		"squote":     func(s interface{}) string { return "'" + toString(s) + "'" },
+
+		// case
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"title": title,
+		"camel": camel,
+		"snake": func(s string) string { return strings.Join(words(s), "_") },
+		"kebab": func(s string) string { return strings.Join(words(s), "-") },
+		"slug":  slug,
+
+		// date
+		"now":  time.Now,
+		"date": date,
+		"unix": func(t time.Time) int64 { return t.Unix() },
+
+		// math
+		"add": func(a, b interface{}) (int64, error) { return intOp(a, b, func(x, y int64) int64 { return x + y }) },
+		"sub": func(a, b interface{}) (int64, error) { return intOp(a, b, func(x, y int64) int64 { return x - y }) },
+		"mul": func(a, b interface{}) (int64, error) { return intOp(a, b, func(x, y int64) int64 { return x * y }) },
+		"div": div,
+		"mod": mod,
+		"max": func(a, b interface{}) (int64, error) { return intOp(a, b, maxInt64) },
+		"min": func(a, b interface{}) (int64, error) { return intOp(a, b, minInt64) },
+
+		// lists
+		"list":      func(items ...interface{}) []interface{} { return items },
+		"first":     first,
+		"last":      last,
+		"has":       has,
+		"uniq":      uniq,
+		"sortAlpha": sortAlpha,
+
+		// encoding
+		"b64enc":     func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+		"b64dec":     b64dec,
+		"toJson":     toJSON,
+		"fromJson":   fromJSON,
+		"urlEncode":  url.QueryEscape,
+		"urlDecode":  url.QueryUnescape,
+		"pathEscape": url.PathEscape,
+
+		// hashing
+		"md5sum":    func(s string) string { return fmt.Sprintf("%x", md5.Sum([]byte(s))) },
+		"sha1sum":   func(s string) string { return fmt.Sprintf("%x", sha1.Sum([]byte(s))) },
+		"sha256sum": func(s string) string { return fmt.Sprintf("%x", sha256.Sum256([]byte(s))) },
+
+		// defaults
+		"default":  defaultValue,
+		"empty":    empty,
+		"coalesce": coalesce,
+		"ternary":  ternary,
+		"required": required,
+	}
+}
+
+// includeUnavailable is the registered {{ include "path" }} function outside of a builtin template render.  Template
+// renders replace it with an include function that resolves paths relative to the rendered template
+func includeUnavailable(name string, data ...interface{}) (string, error) {
+	return "", fmt.Errorf("unable to include '%s' outside of a template render", name)
+}
+
+// wordBoundaryRegEx matches the runs of non-alphanumeric characters that separate words for case conversions
+var wordBoundaryRegEx = regexp.MustCompile(`[^\pL\pN]+`)
+
+// words splits s into lowercase words on non-alphanumeric characters and lower to upper case transitions
+func words(s string) []string {
+	var result []string
+	for _, field := range wordBoundaryRegEx.Split(s, -1) {
+		if len(field) == 0 {
+			continue
+		}
+		runes := []rune(field)
+		start := 0
+		for i := 1; i < len(runes); i++ {
+			if unicode.IsUpper(runes[i]) && unicode.IsLower(runes[i-1]) {
+				result = append(result, strings.ToLower(string(runes[start:i])))
+				start = i
+			}
+		}
+		result = append(result, strings.ToLower(string(runes[start:])))
+	}
+	return result
+}
+
+// title converts the first character of each word in s to upper case
+func title(s string) string {
+	runes := []rune(s)
+	for i := range runes {
+		if i == 0 || unicode.IsSpace(runes[i-1]) {
+			runes[i] = unicode.ToUpper(runes[i])
+		}
+	}
+	return string(runes)
+}
+
+// camel converts s to lower camel case (e.g. "release notes" = "releaseNotes")
+func camel(s string) string {
+	result := ""
+	for i, word := range words(s) {
+		if i > 0 {
+			word = title(word)
+		}
+		result += word
+	}
+	return result
+}
+
+// slugFolds maps lowercase accented Latin letters to their ASCII letters
+var slugFolds = map[rune]string{'ß': "ss", 'æ': "ae", 'œ': "oe", 'þ': "th", 'ð': "d"}
+
+func init() {
+	for ascii, letters := range map[string]string{
+		"a": "àáâãäåāăą", "c": "çćĉċč", "d": "ďđ", "e": "èéêëēĕėęě", "g": "ĝğġģ", "h": "ĥħ", "i": "ìíîïĩīĭįı",
+		"j": "ĵ", "k": "ķ", "l": "ĺļľŀł", "n": "ñńņňŉ", "o": "òóôõöøōŏő", "r": "ŕŗř", "s": "śŝşš", "t": "ţťŧ",
+		"u": "ùúûüũūŭůűų", "w": "ŵ", "y": "ýÿŷ", "z": "źżž",
+	} {
+		for _, letter := range letters {
+			slugFolds[letter] = ascii
+		}
+	}
+}
+
+// slug converts s to a URL slug of lowercase ASCII letters, digits, and single hyphens (e.g. "Crème Brûlée, 2017!" =
+// "creme-brulee-2017").  Accented Latin letters are converted to ASCII letters, apostrophes and other non-ASCII
+// letters and digits are removed, and all other characters separate words
+func slug(s string) string {
+	var result strings.Builder
+	separate := false
+	for _, r := range strings.ToLower(s) {
+		text := string(r)
+		if folded, ok := slugFolds[r]; ok {
+			text = folded
+		} else if r == '\'' || r == '’' || (r > unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsNumber(r))) {
+			continue
+		} else if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9') {
+			separate = result.Len() > 0
+			continue
+		}
+		if separate {
+			result.WriteByte('-')
+			separate = false
+		}
+		result.WriteString(text)
+	}
+	return result.String()
+}
+
+// join joins the string representations of the items in list with sep
+func join(sep string, list interface{}) (string, error) {
+	items, err := toSlice(list)
+	if err != nil {
+		return "", err
+	}
+	itemStrings := make([]string, len(items))
+	for i, item := range items {
+		itemStrings[i] = toString(item)
+	}
+	return strings.Join(itemStrings, sep), nil
+}
+
+// indent indents every line in s with spaces number of space characters
+func indent(spaces int, s string) string {
+	padding := strings.Repeat(" ", spaces)
+	return padding + strings.Replace(s, "\n", "\n"+padding, -1)
+}
+
+// trunc truncates s to length characters
+func trunc(length int, s string) string {
+	runes := []rune(s)
+	if length < 0 || length >= len(runes) {
+		return s
+	}
+	return string(runes[:length])
+}
+
+// date formats the time t (time.Time or Unix seconds) with the Go reference time layout
+func date(layout string, t interface{}) (string, error) {
+	switch value := t.(type) {
+	case time.Time:
+		return value.Format(layout), nil
+	case *time.Time:
+		return value.Format(layout), nil
+	}
+	seconds, err := toInt64(t)
+	if err != nil {
+		return "", fmt.Errorf("date function requires a time value or Unix seconds, received %v", t)
+	}
+	return time.Unix(seconds, 0).Format(layout), nil
+}
+
+// intOp converts a and b to integers and returns the result of op
+func intOp(a, b interface{}, op func(x, y int64) int64) (int64, error) {
+	x, err := toInt64(a)
+	if err != nil {
+		return 0, err
+	}
+	y, err := toInt64(b)
+	if err != nil {
+		return 0, err
+	}
+	return op(x, y), nil
+}
+
+// div returns the integer quotient a / b
+func div(a, b interface{}) (int64, error) {
+	y, err := toInt64(b)
+	if err != nil {
+		return 0, err
+	}
+	if y == 0 {
+		return 0, fmt.Errorf("division by zero")
+	}
+	return intOp(a, y, func(x, y int64) int64 { return x / y })
+}
+
+// mod returns the integer remainder a % b
+func mod(a, b interface{}) (int64, error) {
+	y, err := toInt64(b)
+	if err != nil {
+		return 0, err
+	}
+	if y == 0 {
+		return 0, fmt.Errorf("division by zero")
+	}
+	return intOp(a, y, func(x, y int64) int64 { return x % y })
+}
+
+func maxInt64(x, y int64) int64 {
+	if x > y {
+		return x
+	}
+	return y
+}
+
+func minInt64(x, y int64) int64 {
+	if x < y {
+		return x
+	}
+	return y
+}
+
+// first returns the first item in list or nil if the list is empty
+func first(list interface{}) (interface{}, error) {
+	items, err := toSlice(list)
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	return items[0], nil
+}
+
+// last returns the last item in list or nil if the list is empty
+func last(list interface{}) (interface{}, error) {
+	items, err := toSlice(list)
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	return items[len(items)-1], nil
+}
+
+// has returns true if list contains needle
+func has(needle interface{}, list interface{}) (bool, error) {
+	items, err := toSlice(list)
+	if err != nil {
+		return false, err
+	}
+	for _, item := range items {
+		if reflect.DeepEqual(item, needle) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// uniq returns the items in list with duplicates removed
+func uniq(list interface{}) ([]interface{}, error) {
+	items, err := toSlice(list)
+	if err != nil {
+		return nil, err
+	}
+	var result []interface{}
+	for _, item := range items {
+		if found, _ := has(item, result); !found {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+// sortAlpha returns the string representations of the items in list in alphabetical order
+func sortAlpha(list interface{}) ([]string, error) {
+	items, err := toSlice(list)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, len(items))
+	for i, item := range items {
+		result[i] = toString(item)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// b64dec decodes the base64 encoded string s
+func b64dec(s string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+	return string(decoded), nil
+}
+
+// toJSON encodes value as a JSON string
+func toJSON(value interface{}) (string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// fromJSON decodes the JSON string s
+func fromJSON(s string) (interface{}, error) {
+	var value interface{}
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// defaultValue returns value when it is not empty, otherwise defaultVal
+func defaultValue(defaultVal interface{}, value ...interface{}) interface{} {
+	if len(value) == 0 || empty(value[0]) {
+		return defaultVal
+	}
+	return value[0]
+}
+
+// empty returns true if value is nil or the zero value of its type
+func empty(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	rValue := reflect.ValueOf(value)
+	switch rValue.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rValue.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return rValue.IsNil()
+	}
+	return reflect.DeepEqual(value, reflect.Zero(rValue.Type()).Interface())
+}
+
+// coalesce returns the first value that is not empty
+func coalesce(values ...interface{}) interface{} {
+	for _, value := range values {
+		if !empty(value) {
+			return value
+		}
+	}
+	return nil
+}
+
+// ternary returns trueVal if condition is true, otherwise falseVal
+func ternary(trueVal, falseVal interface{}, condition bool) interface{} {
+	if condition {
+		return trueVal
+	}
+	return falseVal
+}
+
+// required returns value when it is not empty, otherwise an error with the message
+func required(message string, value interface{}) (interface{}, error) {
+	if empty(value) {
+		return nil, fmt.Errorf("%s", message)
+	}
+	return value, nil
+}
+
+// toString returns the string representation of value
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// toInt64 converts integer, float, and numeric string values to int64
+func toInt64(value interface{}) (int64, error) {
+	rValue := reflect.ValueOf(value)
+	switch rValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rValue.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(rValue.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return int64(rValue.Float()), nil
+	case reflect.String:
+		return strconv.ParseInt(strings.TrimSpace(rValue.String()), 10, 64)
+	}
+	return 0, fmt.Errorf("unable to convert %v to an integer", value)
+}
+
+// toSlice converts array and slice values to []interface{}
+func toSlice(list interface{}) ([]interface{}, error) {
+	rValue := reflect.ValueOf(list)
+	switch rValue.Kind() {
+	case reflect.Array, reflect.Slice:
+		items := make([]interface{}, rValue.Len())
+		for i := range items {
+			items[i] = rValue.Index(i).Interface()
+		}
+		return items, nil
+	case reflect.Invalid:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("expected a list value, received %v", list)
+}
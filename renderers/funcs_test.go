@@ -0,0 +1,88 @@
+package renderers
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	tests := []struct {
+		templatetext string
+		expected     string
+	}{
+		// strings
+		{`{{ trim "  ink  " }}`, "ink"},
+		{`{{ "v0.8.0" | trimPrefix "v" }}`, "0.8.0"},
+		{`{{ "ink.txt.in" | trimSuffix ".in" }}`, "ink.txt"},
+		{`{{ "a-b-c" | replace "-" "." }}`, "a.b.c"},
+		{`{{ contains "nk" "ink" }} {{ hasPrefix "i" "ink" }} {{ hasSuffix "x" "ink" }}`, "true true false"},
+		{`{{ repeat 3 "饂" }}`, "饂饂饂"},
+		{`{{ split "," "a,b,c" | join "+" }}`, "a+b+c"},
+		{`{{ indent 2 "a\nb" }}`, "  a\n  b"},
+		{`{{ trunc 3 "åß∂ƒç" }}`, "åß∂"},
+		{`{{ quote "ink" }} {{ squote "ink" }}`, `"ink" 'ink'`},
+		// case
+		{`{{ upper "ink" }} {{ lower "INK" }} {{ title "release notes" }}`, "INK ink Release Notes"},
+		{`{{ camel "release notes" }} {{ snake "ReleaseNotes" }} {{ kebab "release_notes" }}`, "releaseNotes release_notes release-notes"},
+		{`{{ slug "Hello, World! 2017" }}`, "hello-world-2017"},
+		{`{{ slug "  Crème Brûlée -- Straße's Œuvre!  " }} {{ slug "ReleaseNotes" }} {{ slug "饂飩 v1.2_beta" }}`, "creme-brulee-strasses-oeuvre releasenotes v1-2-beta"},
+		// date
+		{`{{ date "2006-01-02" 0 | len }}`, "10"},
+		{`{{ now | date "2006" | len }}`, "4"},
+		// math
+		{`{{ add 1 2 }} {{ sub 5 2 }} {{ mul 3 4 }} {{ div 9 2 }} {{ mod 9 2 }} {{ max 1 2 }} {{ min 1 2 }}`, "3 3 12 4 1 2 1"},
+		// lists
+		{`{{ list "b" "a" "b" | uniq | sortAlpha | join "," }}`, "a,b"},
+		{`{{ list 1 2 3 | first }} {{ list 1 2 3 | last }} {{ list 1 2 3 | has 2 }}`, "1 3 true"},
+		// encoding
+		{`{{ b64enc "ink" }} {{ b64dec "aW5r" }}`, "aW5r ink"},
+		{`{{ list "a" 1 | toJson }} {{ (fromJson "{\"a\": 1}").a }}`, `["a",1] 1`},
+		{`{{ urlEncode "a b&c" }} {{ urlDecode "a+b%26c" }} {{ pathEscape "a b" }}`, "a+b%26c a b&c a%20b"},
+		// hashing
+		{`{{ md5sum "ink" }}`, "0a16bc32f55683128983f223de242942"},
+		{`{{ sha1sum "ink" }}`, "ec437ab60af367f0fec0f6a3cd47f9cbad7b487f"},
+		{`{{ sha256sum "" }}`, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		// defaults
+		{`{{ "" | default "none" }} {{ "ink" | default "none" }}`, "none ink"},
+		{`{{ empty "" }} {{ empty 1 }} {{ coalesce "" "ink" }} {{ ternary "yes" "no" true }}`, "true false ink yes"},
+		{`{{ required "value is required" "ink" }}`, "ink"},
+	}
+
+	for _, testcase := range tests {
+		tmpl, parseerr := template.New("ink").Funcs(TemplateFuncs()).Parse(testcase.templatetext)
+		if parseerr != nil {
+			t.Errorf("[FAIL] Unable to parse template '%s': %v", testcase.templatetext, parseerr)
+			continue
+		}
+		buf := new(bytes.Buffer)
+		if executeerr := tmpl.Execute(buf, nil); executeerr != nil {
+			t.Errorf("[FAIL] Unable to execute template '%s': %v", testcase.templatetext, executeerr)
+			continue
+		}
+		if buf.String() != testcase.expected {
+			t.Errorf("[FAIL] Expected template '%s' to render '%s' and received '%s'", testcase.templatetext, testcase.expected, buf.String())
+		}
+	}
+}
+
+func TestTemplateFuncsErrors(t *testing.T) {
+	tests := []string{
+		`{{ required "value is required" "" }}`,
+		`{{ div 1 0 }}`,
+		`{{ add "one" 1 }}`,
+		`{{ b64dec "%%%" }}`,
+		`{{ join "," "not a list" }}`,
+	}
+
+	for _, templatetext := range tests {
+		tmpl, parseerr := template.New("ink").Funcs(TemplateFuncs()).Parse(templatetext)
+		if parseerr != nil {
+			t.Errorf("[FAIL] Unable to parse template '%s': %v", templatetext, parseerr)
+			continue
+		}
+		if executeerr := tmpl.Execute(new(bytes.Buffer), nil); executeerr == nil {
+			t.Errorf("[FAIL] Expected template '%s' to return an execution error and the error value was 'nil'", templatetext)
+		}
+	}
+}
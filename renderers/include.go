@@ -0,0 +1,217 @@
+// include holds the template include implementation for builtin template rendering
+/*
+MIT License
+
+Copyright (c) 2017 Chris Simpkins
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package renderers
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/chrissimpkins/ink/inkio"
+)
+
+// MaxIncludeDepth is the maximum depth of nested {{ include "path" }} template function calls in builtin templates
+const MaxIncludeDepth = 16
+
+// ResolveIncludePath returns the path or URL of the included template name relative to the directory of the
+// including template at templateSource (local file path or URL).  Remote templates may only include templates with
+// the same URL scheme and host so that computed include names cannot send template data (e.g. environment variables)
+// to other hosts
+func ResolveIncludePath(templateSource string, name string) (string, error) {
+	if inkio.IsURL(templateSource) {
+		baseURL, baseerr := url.Parse(templateSource)
+		if baseerr != nil {
+			return "", baseerr
+		}
+		includeURL, includeerr := url.Parse(filepath.ToSlash(name))
+		if includeerr != nil {
+			return "", includeerr
+		}
+		resolvedURL := baseURL.ResolveReference(includeURL)
+		if resolvedURL.Scheme != baseURL.Scheme || resolvedURL.Host != baseURL.Host {
+			return "", fmt.Errorf("remote template '%s' cannot include '%s' from a different scheme or host", templateSource, name)
+		}
+		return resolvedURL.String(), nil
+	}
+	if inkio.IsURL(name) {
+		return name, nil
+	}
+	if filepath.IsAbs(name) {
+		return filepath.Clean(name), nil
+	}
+	return filepath.Join(filepath.Dir(templateSource), name), nil
+}
+
+// TemplateIncludes returns the string literal template names that are used with the {{ include "path" }} template
+// function in the builtin template text templateText.  The template text may begin with a front matter block
+func TemplateIncludes(templateText string) ([]string, error) {
+	frontMatter, bodyText, _, frontmattererr := SplitFrontMatter(templateText)
+	if frontmattererr != nil {
+		return nil, frontmattererr
+	}
+	leftDelim, rightDelim := LeftDelim, RightDelim
+	if frontMatter != nil && len(frontMatter.LeftDelim) > 0 {
+		leftDelim, rightDelim = frontMatter.LeftDelim, frontMatter.RightDelim
+	}
+	t, parseerr := template.New("ink").Delims(leftDelim, rightDelim).Funcs(TemplateFuncs()).Parse(bodyText)
+	if parseerr != nil {
+		return nil, parseerr
+	}
+
+	var includes []string
+	for _, tmpl := range t.Templates() {
+		if tmpl.Tree == nil {
+			continue
+		}
+		WalkParseTree(tmpl.Tree.Root, func(node parse.Node) {
+			command, ok := node.(*parse.CommandNode)
+			if !ok || len(command.Args) < 2 {
+				return
+			}
+			identifier, isIdentifier := command.Args[0].(*parse.IdentifierNode)
+			name, isString := command.Args[1].(*parse.StringNode)
+			if isIdentifier && isString && identifier.Ident == "include" {
+				includes = append(includes, name.Text)
+			}
+		})
+	}
+
+	return includes, nil
+}
+
+// TemplateDependencies returns the local file path of the builtin template templatePath followed by the local file
+// paths of the templates that it includes, directly or through nested includes, with string literal {{ include "path" }}
+// template function calls.  Included URLs are not returned.  Included files that cannot be read or parsed are returned
+// without their own includes so that changes to them can still be detected
+func TemplateDependencies(templatePath string) []string {
+	var dependencies []string
+	found := make(map[string]bool)
+	var visit func(path string, depth int)
+	visit = func(path string, depth int) {
+		if found[path] {
+			return
+		}
+		found[path] = true
+		dependencies = append(dependencies, path)
+		if depth >= MaxIncludeDepth {
+			return
+		}
+		templateText, readerr := inkio.ReadFileToString(path)
+		if readerr != nil {
+			return
+		}
+		names, parseerr := TemplateIncludes(templateText)
+		if parseerr != nil {
+			return
+		}
+		for _, name := range names {
+			if inkio.IsURL(name) {
+				continue
+			}
+			if includePath, resolveerr := ResolveIncludePath(path, name); resolveerr == nil {
+				visit(includePath, depth+1)
+			}
+		}
+	}
+	visit(templatePath, 0)
+	return dependencies
+}
+
+// WalkParseTree calls visit for node and every node that is nested in node in a text/template parse tree
+func WalkParseTree(node parse.Node, visit func(parse.Node)) {
+	if node == nil {
+		return
+	}
+	visit(node)
+	switch n := node.(type) {
+	case *parse.ListNode:
+		for _, child := range n.Nodes {
+			WalkParseTree(child, visit)
+		}
+	case *parse.ActionNode:
+		WalkParseTree(n.Pipe, visit)
+	case *parse.PipeNode:
+		for _, command := range n.Cmds {
+			WalkParseTree(command, visit)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			WalkParseTree(arg, visit)
+		}
+	case *parse.IfNode:
+		walkBranch(&n.BranchNode, visit)
+	case *parse.RangeNode:
+		walkBranch(&n.BranchNode, visit)
+	case *parse.WithNode:
+		walkBranch(&n.BranchNode, visit)
+	case *parse.TemplateNode:
+		if n.Pipe != nil {
+			WalkParseTree(n.Pipe, visit)
+		}
+	}
+}
+
+// walkBranch walks the pipeline and lists of if, range, and with parse tree nodes
+func walkBranch(branch *parse.BranchNode, visit func(parse.Node)) {
+	WalkParseTree(branch.Pipe, visit)
+	WalkParseTree(branch.List, visit)
+	if branch.ElseList != nil {
+		WalkParseTree(branch.ElseList, visit)
+	}
+}
+
+// includeTemplate renders the template name that is included by the template at templateSource.  The included
+// template is rendered with the optional includeData argument, or with the data of the including template when
+// includeData is not defined, and with the render settings of the including template.  includeChain holds the
+// sources of all including templates for cycle detection
+func includeTemplate(templateSource string, name string, data interface{}, includeData []interface{}, settings renderSettings, includeChain []string) (string, error) {
+	includePath, resolveerr := ResolveIncludePath(templateSource, name)
+	if resolveerr != nil {
+		return "", resolveerr
+	}
+	for _, includingSource := range includeChain {
+		if includingSource == includePath {
+			return "", fmt.Errorf("include cycle detected: %s -> %s", strings.Join(includeChain, " -> "), includePath)
+		}
+	}
+	if len(includeChain) > MaxIncludeDepth {
+		return "", fmt.Errorf("maximum include depth of %d exceeded at '%s'", MaxIncludeDepth, includePath)
+	}
+
+	includeText, readerr := inkio.ReadPathOrURL(includePath)
+	if readerr != nil {
+		return "", fmt.Errorf("unable to read included template '%s'. %v", includePath, readerr)
+	}
+	if len(includeData) > 0 {
+		data = includeData[0]
+	}
+
+	chain := append(append([]string{}, includeChain...), includePath)
+	return executeInkTemplate(includePath, name, includeText, data, settings, chain)
+}
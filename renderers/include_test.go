@@ -0,0 +1,128 @@
+package renderers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRenderBuiltinIncludeLocal(t *testing.T) {
+	replacement := "abcd123"
+	expected := "# header abcd123\nbody=abcd123\nrow=饂饂 footer"
+	haystack, err := RenderFromLocalInkTemplate(filepath.Join("..", "testfiles", "include", "template_include.txt.in"), &replacement)
+	if err != nil {
+		t.Errorf("[FAIL] RenderFromInkTemplate execution returned error value: %v", err)
+	}
+	if *haystack != expected {
+		t.Errorf("[FAIL] Expected rendered template value = '%s' and received rendered template value '%s'", expected, *haystack)
+	}
+}
+
+func TestRenderBuiltinIncludeCycleRaisesError(t *testing.T) {
+	replacement := "abcd123"
+	_, err := RenderFromLocalInkTemplate(filepath.Join("..", "testfiles", "include", "template_cycle_a.txt.in"), &replacement)
+	if err == nil || !strings.Contains(err.Error(), "include cycle") {
+		t.Errorf("[FAIL] Expected include cycle error to be raised and received: %v", err)
+	}
+}
+
+func TestRenderBuiltinIncludeMissingRaisesError(t *testing.T) {
+	replacement := "abcd123"
+	_, err := RenderFromLocalInkTemplate(filepath.Join("..", "testfiles", "include", "template_include_missing.txt.in"), &replacement)
+	if err == nil {
+		t.Errorf("[FAIL] Expected error to be raised for a missing included template and the error value was 'nil'")
+	}
+}
+
+func TestResolveIncludePath(t *testing.T) {
+	tests := []struct {
+		source   string
+		name     string
+		expected string
+	}{
+		{filepath.Join("templates", "page.txt.in"), "header.txt.in", filepath.Join("templates", "header.txt.in")},
+		{filepath.Join("templates", "page.txt.in"), filepath.Join("..", "header.txt.in"), "header.txt.in"},
+		{"https://test.com/templates/page.txt.in", "header.txt.in", "https://test.com/templates/header.txt.in"},
+		{"https://test.com/templates/page.txt.in", "../partials/header.txt.in", "https://test.com/partials/header.txt.in"},
+		{"https://test.com/templates/page.txt.in", "https://test.com/header.txt.in", "https://test.com/header.txt.in"},
+		{filepath.Join("templates", "page.txt.in"), "https://other.com/header.txt.in", "https://other.com/header.txt.in"},
+	}
+
+	for _, testcase := range tests {
+		response, err := ResolveIncludePath(testcase.source, testcase.name)
+		if err != nil {
+			t.Errorf("[FAIL] Did not expect error returned from ResolveIncludePath, received: %v", err)
+		}
+		if response != testcase.expected {
+			t.Errorf("[FAIL] Expected ResolveIncludePath to return '%s', received: '%s'", testcase.expected, response)
+		}
+	}
+
+	crossOrigin := []string{"https://other.com/header.txt.in", "http://test.com/header.txt.in", "//other.com/header.txt.in", "file:///etc/passwd"}
+	for _, name := range crossOrigin {
+		if _, err := ResolveIncludePath("https://test.com/templates/page.txt.in", name); err == nil {
+			t.Errorf("[FAIL] Expected ResolveIncludePath to reject the cross-origin include '%s' of a remote template", name)
+		}
+	}
+}
+
+func TestRenderBuiltinIncludeRemoteCrossOriginRaisesError(t *testing.T) {
+	requested := false
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+		w.Write([]byte("leaked"))
+	}))
+	defer other.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`sha={{ include (printf "` + other.URL + `/%s" .Ink) }}`))
+	}))
+	defer server.Close()
+
+	replacement := "abcd123"
+	_, err := RenderFromRemoteInkTemplate(server.URL+"/template.txt.in", &replacement)
+	if err == nil || !strings.Contains(err.Error(), "different scheme or host") {
+		t.Errorf("[FAIL] Expected cross-origin include error to be raised and received: %v", err)
+	}
+	if requested {
+		t.Errorf("[FAIL] Expected the cross-origin included template not to be requested")
+	}
+}
+
+func TestTemplateIncludes(t *testing.T) {
+	includes, err := TemplateIncludes(`{{ include "a.txt.in" }}{{ if true }}{{ include "b.txt.in" . | upper }}{{ end }}`)
+	if err != nil {
+		t.Errorf("[FAIL] Did not expect error returned from TemplateIncludes, received: %v", err)
+	}
+	if strings.Join(includes, ",") != "a.txt.in,b.txt.in" {
+		t.Errorf("[FAIL] Expected TemplateIncludes to return [a.txt.in b.txt.in], received: %v", includes)
+	}
+}
+
+func TestTemplateDependencies(t *testing.T) {
+	includeDir := filepath.Join("..", "testfiles", "include")
+	tests := []struct {
+		templatepath string
+		expected     []string
+	}{
+		{filepath.Join(includeDir, "template_include.txt.in"), []string{
+			filepath.Join(includeDir, "template_include.txt.in"),
+			filepath.Join(includeDir, "partials", "header.txt.in"),
+			filepath.Join(includeDir, "partials", "row.txt.in"),
+			filepath.Join(includeDir, "footer.txt.in"),
+		}},
+		{filepath.Join(includeDir, "template_cycle_a.txt.in"), []string{
+			filepath.Join(includeDir, "template_cycle_a.txt.in"),
+			filepath.Join(includeDir, "template_cycle_b.txt.in"),
+		}},
+		{"completelybogus.txt.in", []string{"completelybogus.txt.in"}},
+	}
+
+	for _, testcase := range tests {
+		dependencies := TemplateDependencies(testcase.templatepath)
+		if strings.Join(dependencies, ",") != strings.Join(testcase.expected, ",") {
+			t.Errorf("[FAIL] Expected TemplateDependencies to return %v, received: %v", testcase.expected, dependencies)
+		}
+	}
+}
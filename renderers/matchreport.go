@@ -0,0 +1,129 @@
+// matchreport holds the user template match report functions for the ink application
+/*
+MIT License
+
+Copyright (c) 2017 Chris Simpkins
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package renderers
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MatchReport holds the --find= definition matches of a user template render
+type MatchReport struct {
+	Matches int          `json:"matches"`           // number of replaced matches
+	Lines   []int        `json:"lines,omitempty"`   // template text line numbers with matched text in ascending order
+	Changes []LineChange `json:"changes,omitempty"` // changed template text lines
+}
+
+// LineChange holds the template text lines that include one or more matches before and after the replacements
+type LineChange struct {
+	Line   int    `json:"line"`   // first template text line number
+	Before string `json:"before"` // template text lines without the final newline
+	After  string `json:"after"`  // rendered text of the template text lines without the final newline
+}
+
+// findMatches returns the start and end indices of the non-overlapping matches of the --find= definition findString in
+// templateText in the order that they are replaced.  For {{regex}} definitions (non-nil userRegEx), the indices of
+// the capture groups follow the start and end indices of each match
+func findMatches(templateText string, findString string, userRegEx *regexp.Regexp) [][]int {
+	if userRegEx != nil {
+		return userRegEx.FindAllStringSubmatchIndex(templateText, -1)
+	}
+	var matches [][]int
+	for offset := 0; len(findString) > 0; {
+		index := strings.Index(templateText[offset:], findString)
+		if index == -1 {
+			break
+		}
+		start := offset + index
+		offset = start + len(findString)
+		matches = append(matches, []int{start, offset})
+	}
+	return matches
+}
+
+// newMatchReport returns the MatchReport for the matches of a --find= definition in templateText.  replacements holds
+// the replacement text of each match
+func newMatchReport(templateText string, matches [][]int, replacements []string) *MatchReport {
+	report := &MatchReport{Matches: len(matches)}
+	lineStarts := []int{0} // template text offsets of the line starts
+	for i := 0; i < len(templateText); i++ {
+		if templateText[i] == '\n' {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+	// lineOf returns the zero-based line index of the template text offset
+	lineOf := func(offset int) int {
+		return sort.Search(len(lineStarts), func(i int) bool { return lineStarts[i] > offset }) - 1
+	}
+	// lineEnd returns the template text offset of the end of the line with the zero-based line index, without the newline
+	lineEnd := func(line int) int {
+		if line+1 < len(lineStarts) {
+			return lineStarts[line+1] - 1
+		}
+		return len(templateText)
+	}
+
+	lastLine := -1
+	for i := 0; i < len(matches); {
+		// group the matches on the same template text lines into a single change
+		firstLine := lineOf(matches[i][0])
+		var after strings.Builder
+		offset := lineStarts[firstLine]
+		endLine := firstLine
+		for ; i < len(matches) && lineOf(matches[i][0]) <= endLine; i++ {
+			start, end := matches[i][0], matches[i][1]
+			matchEndLine := lineOf(start)
+			if end > start {
+				matchEndLine = lineOf(end - 1)
+			}
+			for line := lineOf(start); line <= matchEndLine; line++ {
+				if line > lastLine {
+					report.Lines = append(report.Lines, line+1)
+					lastLine = line
+				}
+			}
+			if matchEndLine > endLine {
+				endLine = matchEndLine
+			}
+			if line := lineOf(end); line > endLine && end < len(templateText) {
+				endLine = line // a match that ends with a newline joins the following line in the rendered text
+			}
+			after.WriteString(templateText[offset:start])
+			after.WriteString(replacements[i])
+			offset = end
+		}
+		if offset < lineEnd(endLine) { // the last match may end with the final newline of the template text
+			after.WriteString(templateText[offset:lineEnd(endLine)])
+		}
+		report.Changes = append(report.Changes, LineChange{
+			Line:   firstLine + 1,
+			Before: templateText[lineStarts[firstLine]:lineEnd(endLine)],
+			After:  after.String(),
+		})
+	}
+	return report
+}
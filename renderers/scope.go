@@ -0,0 +1,152 @@
+// scope holds the user template substitution scope functions for the ink application
+/*
+MIT License
+
+Copyright (c) 2017 Chris Simpkins
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package renderers
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// LineRange is an inclusive range of template text line numbers
+type LineRange struct {
+	First int // first line number, 1 or greater
+	Last  int // last line number, 0 for the last line of the template text
+}
+
+// LineRanges is a global variable that holds the template text line ranges that user template substitutions are
+// restricted to.  All lines are in scope when undefined (nil)
+var LineRanges []LineRange
+
+// RegionStart is a global variable that holds the marker line pattern that starts a region of the template text that
+// user template substitutions are restricted to (e.g. `# BEGIN ink`).  The region ends at the next line that matches
+// RegionEnd, or at the end of the template text, and the marker lines are not in the region.  All lines are in scope
+// when undefined (nil)
+var RegionStart *regexp.Regexp
+
+// RegionEnd is a global variable that holds the marker line pattern that ends a RegionStart region (e.g. `# END ink`)
+var RegionEnd *regexp.Regexp
+
+// ParseLineRanges parses the comma separated line number and line number range definition lineRanges (e.g.
+// "3,10-20,40-") and returns the line ranges
+func ParseLineRanges(lineRanges string) ([]LineRange, error) {
+	var ranges []LineRange
+	for _, definition := range strings.Split(lineRanges, ",") {
+		definition = strings.TrimSpace(definition)
+		first, last := definition, definition
+		if dashIndex := strings.Index(definition, "-"); dashIndex != -1 {
+			first, last = definition[:dashIndex], definition[dashIndex+1:]
+		}
+		lineRange := LineRange{First: 1}
+		var firsterr, lasterr error
+		if len(first) > 0 {
+			lineRange.First, firsterr = strconv.Atoi(first)
+		}
+		if len(last) > 0 {
+			lineRange.Last, lasterr = strconv.Atoi(last)
+		}
+		if len(first) == 0 && len(last) == 0 || firsterr != nil || lasterr != nil || lineRange.First < 1 || lineRange.Last < 0 || (lineRange.Last > 0 && lineRange.Last < lineRange.First) || (len(last) > 0 && lineRange.Last == 0) {
+			return nil, fmt.Errorf("'%s' is not a line number or a line number range (e.g. 3, 10-20, 40-)", definition)
+		}
+		ranges = append(ranges, lineRange)
+	}
+	return ranges, nil
+}
+
+// scopeIntervals returns the start and end offsets of the template text intervals that are in the LineRanges and
+// RegionStart scope of user template substitutions.  Intervals include the newline of their last line.  Returns nil
+// when the scope is not restricted
+func scopeIntervals(templateText string) [][2]int {
+	if LineRanges == nil && RegionStart == nil {
+		return nil
+	}
+	intervals := [][2]int{}
+	inRegion := false
+	offset := 0
+	for lineNumber := 1; offset < len(templateText); lineNumber++ {
+		lineEnd := len(templateText)
+		if newlineIndex := strings.IndexByte(templateText[offset:], '\n'); newlineIndex != -1 {
+			lineEnd = offset + newlineIndex + 1
+		}
+		line := strings.TrimRight(templateText[offset:lineEnd], "\r\n")
+		inScope := inLineRanges(lineNumber)
+		if RegionStart != nil {
+			switch {
+			case !inRegion && RegionStart.MatchString(line):
+				inRegion, inScope = true, false
+			case inRegion && RegionEnd != nil && RegionEnd.MatchString(line):
+				inRegion, inScope = false, false
+			default:
+				inScope = inScope && inRegion
+			}
+		}
+		if inScope {
+			if len(intervals) > 0 && intervals[len(intervals)-1][1] == offset {
+				intervals[len(intervals)-1][1] = lineEnd // extend the interval of the previous line
+			} else {
+				intervals = append(intervals, [2]int{offset, lineEnd})
+			}
+		}
+		offset = lineEnd
+	}
+	return intervals
+}
+
+// inLineRanges returns true when the line number lineNumber is in LineRanges or LineRanges is undefined
+func inLineRanges(lineNumber int) bool {
+	if LineRanges == nil {
+		return true
+	}
+	for _, lineRange := range LineRanges {
+		if lineNumber >= lineRange.First && (lineRange.Last == 0 || lineNumber <= lineRange.Last) {
+			return true
+		}
+	}
+	return false
+}
+
+// findScopedMatches returns the matches of the --find= definition findString (compiled to userRegEx for regular
+// expression definitions) in the scope intervals of templateText.  Each interval is matched separately so that
+// matches do not extend beyond the scope
+func findScopedMatches(templateText string, findString string, userRegEx *regexp.Regexp) [][]int {
+	intervals := scopeIntervals(templateText)
+	if intervals == nil {
+		return findMatches(templateText, findString, userRegEx)
+	}
+	var matches [][]int
+	for _, interval := range intervals {
+		for _, match := range findMatches(templateText[interval[0]:interval[1]], findString, userRegEx) {
+			for i := range match {
+				if match[i] >= 0 { // -1 = unmatched optional capture group
+					match[i] += interval[0]
+				}
+			}
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
@@ -0,0 +1,114 @@
+package renderers
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestParseLineRanges(t *testing.T) {
+	ranges, err := ParseLineRanges("3, 10-20,40-,-2")
+	if err != nil {
+		t.Errorf("[FAIL] ParseLineRanges returned error value: %v", err)
+	}
+	expected := []LineRange{{First: 3, Last: 3}, {First: 10, Last: 20}, {First: 40, Last: 0}, {First: 1, Last: 2}}
+	if !reflect.DeepEqual(ranges, expected) {
+		t.Errorf("[FAIL] Expected line ranges %+v, received %+v", expected, ranges)
+	}
+
+	invalid := []string{"", "0", "a", "3,", "20-10", "1-0", "-", "2-x"}
+	for _, definition := range invalid {
+		if _, err := ParseLineRanges(definition); err == nil {
+			t.Errorf("[FAIL] Expected ParseLineRanges('%s') to return an error", definition)
+		}
+	}
+}
+
+func TestRenderUserRulesScoped(t *testing.T) {
+	defer func() {
+		LineRanges, RegionStart, RegionEnd = nil, nil, nil // reset to default values or this interferes with other tests
+	}()
+	templateText := "ink 1\n# BEGIN ink\nink ink\nink\n# END ink\nink 6\n"
+	rule := []SubRule{{Find: "ink", Replace: "INK"}}
+
+	LineRanges = []LineRange{{First: 1, Last: 1}, {First: 4, Last: 0}}
+	rendered, report, err := renderUserRules(templateText, rule, true)
+	if err != nil {
+		t.Errorf("[FAIL] renderUserRules returned error value: %v", err)
+	}
+	expected := "INK 1\n# BEGIN ink\nink ink\nINK\n# END INK\nINK 6\n"
+	if rendered != expected {
+		t.Errorf("[FAIL] Expected --lines rendered template value = '%s' and received rendered template value '%s'", expected, rendered)
+	}
+	if !reflect.DeepEqual(report.Lines, []int{1, 4, 5, 6}) {
+		t.Errorf("[FAIL] Expected --lines match report lines [1 4 5 6], received %v", report.Lines)
+	}
+
+	LineRanges = nil
+	RegionStart, RegionEnd = regexp.MustCompile(`^# BEGIN ink`), regexp.MustCompile(`^# END ink`)
+	rendered, _, err = renderUserRules(templateText, rule, false)
+	if err != nil {
+		t.Errorf("[FAIL] renderUserRules returned error value: %v", err)
+	}
+	expected = "ink 1\n# BEGIN ink\nINK INK\nINK\n# END ink\nink 6\n"
+	if rendered != expected {
+		t.Errorf("[FAIL] Expected region rendered template value = '%s' and received rendered template value '%s'", expected, rendered)
+	}
+
+	// regex matches do not extend beyond the region
+	rendered, _, err = renderUserRules(templateText, []SubRule{{Find: "{{ink\\s+ink}}", Replace: "-", DotAll: true}}, false)
+	if err != nil {
+		t.Errorf("[FAIL] renderUserRules returned error value: %v", err)
+	}
+	expected = "ink 1\n# BEGIN ink\n-\nink\n# END ink\nink 6\n"
+	if rendered != expected {
+		t.Errorf("[FAIL] Expected region regex rendered template value = '%s' and received rendered template value '%s'", expected, rendered)
+	}
+
+	// regions without an end marker line run to the end of the template text
+	RegionStart, RegionEnd = regexp.MustCompile(`^# END ink`), regexp.MustCompile(`^# NONE`)
+	rendered, _, err = renderUserRules(templateText, rule, false)
+	if err != nil {
+		t.Errorf("[FAIL] renderUserRules returned error value: %v", err)
+	}
+	expected = "ink 1\n# BEGIN ink\nink ink\nink\n# END ink\nINK 6\n"
+	if rendered != expected {
+		t.Errorf("[FAIL] Expected unterminated region rendered template value = '%s' and received rendered template value '%s'", expected, rendered)
+	}
+}
+
+func TestRenderUserRulesNth(t *testing.T) {
+	defer func() {
+		LineRanges = nil // reset to default value or this interferes with other tests
+	}()
+	templateText := "ink ink\nink ink"
+	tests := []struct {
+		nth      int
+		expected string
+	}{
+		{0, "INK INK\nINK INK"},
+		{1, "INK ink\nink ink"},
+		{3, "ink ink\nINK ink"},
+		{5, "ink ink\nink ink"},
+	}
+	for _, test := range tests {
+		rendered, _, err := renderUserRules(templateText, []SubRule{{Find: "ink", Replace: "INK", Nth: test.nth}}, false)
+		if err != nil {
+			t.Errorf("[FAIL] renderUserRules returned error value: %v", err)
+		}
+		if rendered != test.expected {
+			t.Errorf("[FAIL] Expected Nth = %d rendered template value = '%s' and received rendered template value '%s'", test.nth, test.expected, rendered)
+		}
+	}
+
+	// the Nth match is counted in the scope
+	LineRanges = []LineRange{{First: 2, Last: 2}}
+	count, err := SubRule{Find: "ink", Nth: 2}.CountMatches(templateText)
+	if err != nil || count != 1 {
+		t.Errorf("[FAIL] Expected 1 scoped Nth match, received %d (%v)", count, err)
+	}
+	rendered, _, err := renderUserRules(templateText, []SubRule{{Find: "ink", Replace: "INK", Nth: 2}}, false)
+	if err != nil || rendered != "ink ink\nink INK" {
+		t.Errorf("[FAIL] Expected scoped Nth rendered template value 'ink ink\\nink INK', received '%s' (%v)", rendered, err)
+	}
+}
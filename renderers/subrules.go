@@ -0,0 +1,425 @@
+// subrules holds the user template substitution rule functions for the ink application
+/*
+MIT License
+
+Copyright (c) 2017 Chris Simpkins
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package renderers
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/chrissimpkins/ink/inkio"
+)
+
+// SubRule is a find and replace substitution rule of a user template render.  Rules are defined with the
+// find=>replace[=>flags] syntax where find is a string literal or a {{regex}} pattern and the optional flags are
+// i (case-insensitive), f (first match only), w (whole word), l (literal replacement mode), t (template replacement
+// mode), r (find is a regular expression pattern without {{ }} delimiters), m (multiline), s (dot matches
+// newlines), and a match number N (replace the Nth match only, e.g. 2).  A \=> sequence in the find and replace fields is a literal => string
+type SubRule struct {
+	Find       string // string literal or {{regex}} find definition
+	Replace    string // replacement string that is interpreted according to the replacement mode Mode
+	IgnoreCase bool   // case-insensitive matches
+	FirstOnly  bool   // replace the first match only
+	WholeWord  bool   // match whole words only
+	Mode       string // replacement mode, ReplaceExpand when undefined
+	Regex      bool   // Find is a regular expression pattern without the {{ }} delimiters
+	Multiline  bool   // ^ and $ match at line starts and ends in addition to the text start and end
+	DotAll     bool   // . matches newline characters
+	Nth        int    // replace the Nth match only, all matches when 0
+}
+
+// Substitution rule replacement modes
+const (
+	ReplaceExpand   = "expand"   // $1, ${1}, and ${name} capture group expansions in {{regex}} rule replacements
+	ReplaceLiteral  = "literal"  // replacement strings are used as-is
+	ReplaceTemplate = "template" // replacement strings are builtin templates that are executed with the match data
+)
+
+// subRuleSeparator separates the find, replace, and flags fields of a substitution rule definition
+const subRuleSeparator = "=>"
+
+// escapedSubRuleSeparator is a literal => string in the find and replace fields of a substitution rule definition
+const escapedSubRuleSeparator = `\` + subRuleSeparator
+
+// splitSubRule splits the substitution rule definition definition on the separators that are not escaped and returns
+// the unescaped fields
+func splitSubRule(definition string) []string {
+	var fields []string
+	var field strings.Builder
+	for len(definition) > 0 {
+		switch {
+		case strings.HasPrefix(definition, escapedSubRuleSeparator):
+			field.WriteString(subRuleSeparator)
+			definition = definition[len(escapedSubRuleSeparator):]
+		case strings.HasPrefix(definition, subRuleSeparator):
+			fields = append(fields, field.String())
+			field.Reset()
+			definition = definition[len(subRuleSeparator):]
+		default:
+			field.WriteByte(definition[0])
+			definition = definition[1:]
+		}
+	}
+	return append(fields, field.String())
+}
+
+// ParseSubRule parses the find=>replace[=>flags] substitution rule definition definition and returns the SubRule
+func ParseSubRule(definition string) (SubRule, error) {
+	fields := splitSubRule(definition)
+	if len(fields) < 2 || len(fields) > 3 {
+		return SubRule{}, fmt.Errorf("substitution rule '%s' does not use the find=>replace[=>flags] syntax", definition)
+	}
+	rule := SubRule{Find: fields[0], Replace: fields[1]}
+	if len(rule.Find) == 0 {
+		return SubRule{}, fmt.Errorf("substitution rule '%s' does not define a find string", definition)
+	}
+	if len(fields) == 3 {
+		nthDigits := ""
+		for _, flag := range fields[2] {
+			switch flag {
+			case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+				nthDigits += string(flag)
+			case 'i':
+				rule.IgnoreCase = true
+			case 'f':
+				rule.FirstOnly = true
+			case 'w':
+				rule.WholeWord = true
+			case 'r':
+				rule.Regex = true
+			case 'm':
+				rule.Multiline = true
+			case 's':
+				rule.DotAll = true
+			case 'l', 't':
+				mode := map[rune]string{'l': ReplaceLiteral, 't': ReplaceTemplate}[flag]
+				if len(rule.Mode) > 0 && rule.Mode != mode {
+					return SubRule{}, fmt.Errorf("substitution rule '%s' defines more than one replacement mode", definition)
+				}
+				rule.Mode = mode
+			default:
+				return SubRule{}, fmt.Errorf("substitution rule '%s' has the unsupported flag '%c' (use i, f, w, l, t, r, m, s, or a match number)", definition, flag)
+			}
+		}
+		if len(nthDigits) > 0 {
+			nth, nterr := strconv.Atoi(nthDigits)
+			if nterr != nil || nth < 1 {
+				return SubRule{}, fmt.Errorf("substitution rule '%s' has the invalid match number '%s'", definition, nthDigits)
+			}
+			rule.Nth = nth
+		}
+	}
+	if validateerr := rule.Validate(); validateerr != nil {
+		return SubRule{}, fmt.Errorf("substitution rule '%s' is not valid. %v", definition, validateerr)
+	}
+	return rule, nil
+}
+
+// Validate returns an error when the rule has an invalid find definition, replacement mode, or replacement template
+func (rule SubRule) Validate() error {
+	if _, compileerr := rule.compile(); compileerr != nil {
+		return fmt.Errorf("invalid find definition. %v", compileerr)
+	}
+	switch rule.Mode {
+	case "", ReplaceExpand, ReplaceLiteral:
+	case ReplaceTemplate:
+		if _, templateerr := rule.replaceTemplate(); templateerr != nil {
+			return fmt.Errorf("invalid replacement template. %v", templateerr)
+		}
+	default:
+		return fmt.Errorf("unsupported replacement mode '%s' (use %s, %s, or %s)", rule.Mode, ReplaceExpand, ReplaceLiteral, ReplaceTemplate)
+	}
+	return nil
+}
+
+// ParseSubRules parses the substitution rules file text rulesText with one find=>replace[=>flags] rule definition
+// per line and returns the rules in file order.  Empty lines and lines that begin with # are ignored
+func ParseSubRules(rulesText string) ([]SubRule, error) {
+	var rules []SubRule
+	for i, line := range strings.Split(rulesText, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if len(strings.TrimSpace(line)) == 0 || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		rule, ruleerr := ParseSubRule(line)
+		if ruleerr != nil {
+			return nil, fmt.Errorf("line %d: %v", i+1, ruleerr)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// ReadSubRulesFile reads and parses the substitution rules file at the local path or URL rulesPath
+func ReadSubRulesFile(rulesPath string) ([]SubRule, error) {
+	rulesText, readerr := inkio.ReadPathOrURL(rulesPath)
+	if readerr != nil {
+		return nil, readerr
+	}
+	return ParseSubRules(rulesText)
+}
+
+// String returns the find=>replace[=>flags] definition of the rule
+func (rule SubRule) String() string {
+	flags := ""
+	if rule.IgnoreCase {
+		flags += "i"
+	}
+	if rule.FirstOnly {
+		flags += "f"
+	}
+	if rule.WholeWord {
+		flags += "w"
+	}
+	switch rule.Mode {
+	case ReplaceLiteral:
+		flags += "l"
+	case ReplaceTemplate:
+		flags += "t"
+	}
+	if rule.Regex {
+		flags += "r"
+	}
+	if rule.Multiline {
+		flags += "m"
+	}
+	if rule.DotAll {
+		flags += "s"
+	}
+	if rule.Nth > 0 {
+		flags += strconv.Itoa(rule.Nth)
+	}
+	definition := strings.Replace(rule.Find, subRuleSeparator, escapedSubRuleSeparator, -1) + subRuleSeparator + strings.Replace(rule.Replace, subRuleSeparator, escapedSubRuleSeparator, -1)
+	if len(flags) > 0 {
+		return definition + subRuleSeparator + flags
+	}
+	return definition
+}
+
+// isRegex returns true when the rule find definition is a regular expression pattern
+func (rule SubRule) isRegex() bool {
+	return rule.Regex || isUserRegex(rule.Find)
+}
+
+// CountMatches returns the number of matches that the rule replaces in templateText
+func (rule SubRule) CountMatches(templateText string) (int, error) {
+	userRegEx, compileerr := rule.compile()
+	if compileerr != nil {
+		return 0, compileerr
+	}
+	return len(rule.matches(templateText, userRegEx)), nil
+}
+
+// matches returns the matches of the rule (compiled to userRegEx for regular expression rules) that are replaced in
+// templateText.  Matches are restricted to the LineRanges and RegionStart scope and to the first or Nth match
+func (rule SubRule) matches(templateText string, userRegEx *regexp.Regexp) [][]int {
+	matches := findScopedMatches(templateText, rule.Find, userRegEx)
+	nth := rule.Nth
+	if rule.FirstOnly {
+		nth = 1
+	}
+	switch {
+	case nth == 0:
+		return matches
+	case nth > len(matches):
+		return nil
+	}
+	return matches[nth-1 : nth]
+}
+
+// compile returns the compiled regular expression of the rule, or nil for string literal rules without flags that
+// require a regular expression
+func (rule SubRule) compile() (*regexp.Regexp, error) {
+	pattern := regexp.QuoteMeta(rule.Find)
+	switch {
+	case rule.Regex:
+		if _, reerr := regexp.Compile(rule.Find); reerr != nil {
+			return nil, reerr
+		}
+		pattern = rule.Find
+	case isUserRegex(rule.Find):
+		userRegEx, userreerr := CompileUserFind(rule.Find)
+		if userreerr != nil {
+			return nil, userreerr
+		}
+		pattern = userRegEx.String()
+	case !rule.IgnoreCase && !rule.WholeWord:
+		return nil, nil
+	}
+	if rule.WholeWord {
+		pattern = `\b(?:` + pattern + `)\b`
+	}
+	flags := ""
+	if rule.IgnoreCase {
+		flags += "i"
+	}
+	if rule.Multiline {
+		flags += "m"
+	}
+	if rule.DotAll {
+		flags += "s"
+	}
+	if len(flags) > 0 {
+		pattern = "(?" + flags + ")" + pattern
+	}
+	return regexp.Compile(pattern)
+}
+
+// apply performs the rule replacements in templateText and returns the rendered text and, when withReport is true,
+// the match report of the rule.  In Strict mode, a rule that does not match the template text returns an error
+func (rule SubRule) apply(templateText string, withReport bool) (string, *MatchReport, error) {
+	userRegEx, compileerr := rule.compile()
+	if compileerr != nil {
+		return "", nil, compileerr
+	}
+	matches := rule.matches(templateText, userRegEx)
+	if Strict && len(matches) == 0 {
+		return "", nil, fmt.Errorf("the find definition '%s' does not match the template text", rule.Find)
+	}
+
+	replacements := make([]string, len(matches))
+	switch {
+	case rule.Mode == ReplaceTemplate:
+		replaceTemplate, templateerr := rule.replaceTemplate()
+		if templateerr != nil {
+			return "", nil, templateerr
+		}
+		for i, match := range matches {
+			var replacement bytes.Buffer
+			if executeerr := replaceTemplate.Execute(&replacement, matchData(templateText, match, userRegEx)); executeerr != nil {
+				return "", nil, executeerr
+			}
+			replacements[i] = replacement.String()
+		}
+	case rule.Mode != ReplaceLiteral && rule.isRegex():
+		// $ expansions are supported in regular expression rule replacements only
+		for i, match := range matches {
+			replacements[i] = string(userRegEx.ExpandString(nil, rule.Replace, templateText, match))
+		}
+	default:
+		for i := range matches {
+			replacements[i] = rule.Replace
+		}
+	}
+
+	var renderedText strings.Builder
+	offset := 0
+	for i, match := range matches {
+		renderedText.WriteString(templateText[offset:match[0]])
+		renderedText.WriteString(replacements[i])
+		offset = match[1]
+	}
+	renderedText.WriteString(templateText[offset:])
+
+	var report *MatchReport
+	if withReport {
+		report = newMatchReport(templateText, matches, replacements)
+	}
+	return renderedText.String(), report, nil
+}
+
+// replaceTemplate returns the parsed replacement template of a ReplaceTemplate mode rule
+func (rule SubRule) replaceTemplate() (*template.Template, error) {
+	t := template.New("replace").Delims(LeftDelim, RightDelim).Funcs(TemplateFuncs())
+	if Strict {
+		t = t.Option("missingkey=error")
+	}
+	return t.Parse(rule.Replace)
+}
+
+// matchData returns the replacement template data of the match with the start and end indices match in
+// templateText.  The data include the TemplateData keys, the match text on the G0 key, the capture group text on the
+// G1 ... Gn keys, and the named capture group text on the group names
+func matchData(templateText string, match []int, userRegEx *regexp.Regexp) map[string]interface{} {
+	data := make(map[string]interface{}, len(TemplateData)+len(match))
+	for key, value := range TemplateData {
+		data[key] = value
+	}
+	var names []string
+	if userRegEx != nil {
+		names = userRegEx.SubexpNames()
+	}
+	for group := 0; 2*group+1 < len(match); group++ {
+		text := "" // unmatched optional capture groups are empty strings
+		if match[2*group] >= 0 {
+			text = templateText[match[2*group]:match[2*group+1]]
+		}
+		data["G"+strconv.Itoa(group)] = text
+		if group < len(names) && len(names[group]) > 0 {
+			data[names[group]] = text
+		}
+	}
+	return data
+}
+
+// renderUserRules applies the substitution rules to templateText in order and returns the rendered text and, when
+// withReport is true, the combined match report of the rules.  Each rule matches the text that the previous rules
+// rendered, and the report line numbers are the line numbers of that text
+func renderUserRules(templateText string, rules []SubRule, withReport bool) (string, *MatchReport, error) {
+	var report *MatchReport
+	if withReport {
+		report = &MatchReport{}
+	}
+	renderedText := templateText
+	for _, rule := range rules {
+		ruleText, ruleReport, ruleerr := rule.apply(renderedText, withReport)
+		if ruleerr != nil {
+			return "", nil, ruleerr
+		}
+		renderedText = ruleText
+		if withReport {
+			report.Matches += ruleReport.Matches
+			report.Lines = mergeLines(report.Lines, ruleReport.Lines)
+			report.Changes = append(report.Changes, ruleReport.Changes...)
+		}
+	}
+	return renderedText, report, nil
+}
+
+// mergeLines returns the sorted union of the ascending line number lists a and b
+func mergeLines(a []int, b []int) []int {
+	merged := make([]int, 0, len(a)+len(b))
+	for len(a) > 0 || len(b) > 0 {
+		switch {
+		case len(b) == 0 || (len(a) > 0 && a[0] < b[0]):
+			merged = append(merged, a[0])
+			a = a[1:]
+		case len(a) == 0 || b[0] < a[0]:
+			merged = append(merged, b[0])
+			b = b[1:]
+		default: // equal line numbers
+			merged = append(merged, a[0])
+			a, b = a[1:], b[1:]
+		}
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	return merged
+}
@@ -0,0 +1,170 @@
+package renderers
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseSubRule(t *testing.T) {
+	tests := []struct {
+		definition string
+		expected   SubRule
+	}{
+		{"Acme=>Globex", SubRule{Find: "Acme", Replace: "Globex"}},
+		{"Acme=>", SubRule{Find: "Acme", Replace: ""}},
+		{"Acme=>Globex=>ifw", SubRule{Find: "Acme", Replace: "Globex", IgnoreCase: true, FirstOnly: true, WholeWord: true}},
+		{"{{(\\d+)\\.0}}=>$1.1=>f", SubRule{Find: "{{(\\d+)\\.0}}", Replace: "$1.1", FirstOnly: true}},
+		{"Acme=>Globex=>i12", SubRule{Find: "Acme", Replace: "Globex", IgnoreCase: true, Nth: 12}},
+	}
+	for _, test := range tests {
+		rule, err := ParseSubRule(test.definition)
+		if err != nil {
+			t.Errorf("[FAIL] ParseSubRule('%s') returned error value: %v", test.definition, err)
+		}
+		if rule != test.expected {
+			t.Errorf("[FAIL] ParseSubRule('%s') expected %+v, received %+v", test.definition, test.expected, rule)
+		}
+		if rule.String() != test.definition {
+			t.Errorf("[FAIL] Expected SubRule.String() = '%s', received '%s'", test.definition, rule.String())
+		}
+	}
+
+	invalid := []string{"Acme", "=>Globex", "a=>b=>c=>d", "a=>b=>z", "{{(}}=>b", "a=>b=>0"}
+	for _, definition := range invalid {
+		if _, err := ParseSubRule(definition); err == nil {
+			t.Errorf("[FAIL] Expected ParseSubRule('%s') to return an error", definition)
+		}
+	}
+}
+
+func TestReadSubRulesFile(t *testing.T) {
+	rules, err := ReadSubRulesFile(filepath.Join("..", "testfiles", "subrules.txt"))
+	if err != nil {
+		t.Errorf("[FAIL] ReadSubRulesFile returned error value: %v", err)
+	}
+	expected := []SubRule{
+		{Find: "Acme", Replace: "Globex", IgnoreCase: true, WholeWord: true},
+		{Find: "{{(\\w+)-tools}}", Replace: "$1-kit"},
+		{Find: "Corp", Replace: "Inc", FirstOnly: true},
+	}
+	if !reflect.DeepEqual(rules, expected) {
+		t.Errorf("[FAIL] Expected rules %+v, received %+v", expected, rules)
+	}
+
+	if _, err := ParseSubRules("a=>b\r\n# comment\nbogus\n"); err == nil || err.Error()[:7] != "line 3:" {
+		t.Errorf("[FAIL] Expected a line 3 error for an invalid rules file rule, received %v", err)
+	}
+	if _, err := ReadSubRulesFile("completelybogus.rules"); err == nil {
+		t.Errorf("[FAIL] Expected error to be raised for invalid file path and the error value was 'nil'")
+	}
+}
+
+func TestRenderUserRules(t *testing.T) {
+	rules := []SubRule{
+		{Find: "Acme", Replace: "Globex", IgnoreCase: true, WholeWord: true},
+		{Find: "{{(\\w+)-tools}}", Replace: "$1-kit"},
+		{Find: "Corp", Replace: "Inc", FirstOnly: true},
+		{Find: "price", Replace: "$5"}, // string literal rules do not expand $ replacements
+	}
+	templateText := "Acme Corp, Corp\nacme-tools by ACME\nAcmeBase price"
+	expected := "Globex Inc, Corp\nGlobex-kit by Globex\nAcmeBase $5"
+	rendered, report, err := renderUserRules(templateText, rules, true)
+	if err != nil {
+		t.Errorf("[FAIL] renderUserRules returned error value: %v", err)
+	}
+	if rendered != expected {
+		t.Errorf("[FAIL] Expected rendered template value = '%s' and received rendered template value '%s'", expected, rendered)
+	}
+	if report.Matches != 6 || !reflect.DeepEqual(report.Lines, []int{1, 2, 3}) || len(report.Changes) != 5 {
+		t.Errorf("[FAIL] Unexpected match report: %+v", report)
+	}
+
+	Strict = true
+	_, _, err = renderUserRules(templateText, append(rules, SubRule{Find: "Initech", Replace: "Initrode"}), false)
+	Strict = false // reset to default value or this interferes with other tests
+	if err == nil {
+		t.Errorf("[FAIL] Expected strict mode render error for a rule that does not match the template text")
+	}
+}
+
+func TestRenderUserRulesRegexFlags(t *testing.T) {
+	templateText := "keep\n// BEGIN gen\nold1\nOLD2\n// END gen\nkeep"
+	tests := []struct {
+		rule     SubRule
+		expected string
+	}{
+		{SubRule{Find: "^// BEGIN gen$.*?^// END gen$", Replace: "// BEGIN gen\nnew\n// END gen", Regex: true, Multiline: true, DotAll: true}, "keep\n// BEGIN gen\nnew\n// END gen\nkeep"},
+		{SubRule{Find: "^// BEGIN gen$.*?^// END gen$", Replace: "x", Regex: true, Multiline: true}, templateText}, // . does not match newlines
+		{SubRule{Find: "{{^old\\d$}}", Replace: "x", Multiline: true, IgnoreCase: true}, "keep\n// BEGIN gen\nx\nx\n// END gen\nkeep"},
+		{SubRule{Find: "{{^old\\d$}}", Replace: "x"}, templateText}, // ^ and $ match at the text start and end
+		{SubRule{Find: "old", Replace: "new", IgnoreCase: true, FirstOnly: true}, "keep\n// BEGIN gen\nnew1\nOLD2\n// END gen\nkeep"},
+		{SubRule{Find: "o(l)d(\\d)", Replace: "$2$1", Regex: true}, "keep\n// BEGIN gen\n1l\nOLD2\n// END gen\nkeep"},
+	}
+	for _, test := range tests {
+		rendered, _, err := renderUserRules(templateText, []SubRule{test.rule}, false)
+		if err != nil {
+			t.Errorf("[FAIL] renderUserRules returned error value for rule '%s': %v", test.rule, err)
+		}
+		if rendered != test.expected {
+			t.Errorf("[FAIL] Expected rendered template value = '%s' and received rendered template value '%s' for rule '%s'", test.expected, rendered, test.rule)
+		}
+	}
+
+	rule, err := ParseSubRule("a \\=> b=>a -> b=>rms")
+	expected := SubRule{Find: "a => b", Replace: "a -> b", Regex: true, Multiline: true, DotAll: true}
+	if err != nil || rule != expected {
+		t.Errorf("[FAIL] Expected rule %+v, received %+v (error %v)", expected, rule, err)
+	}
+	if rule.String() != "a \\=> b=>a -> b=>rms" {
+		t.Errorf("[FAIL] Expected the escaped rule definition, received '%s'", rule.String())
+	}
+	if count, _ := (SubRule{Find: "o", IgnoreCase: true}).CountMatches(templateText); count != 2 {
+		t.Errorf("[FAIL] Expected 2 case-insensitive matches, received %d", count)
+	}
+	if _, err := ParseSubRule("(=>b=>r"); err == nil {
+		t.Errorf("[FAIL] Expected ParseSubRule to return an error for an invalid regular expression pattern")
+	}
+}
+
+func TestRenderUserRulesReplaceModes(t *testing.T) {
+	TemplateData = map[string]interface{}{"regions": map[string]interface{}{"us": "United States"}}
+	defer func() { TemplateData = nil }() // reset to default value or this interferes with other tests
+
+	templateText := "region=us-7 cost=$5"
+	tests := []struct {
+		rule     SubRule
+		expected string
+	}{
+		{SubRule{Find: "{{([a-z]+)-(\\d)}}", Replace: "${2}-$1"}, "region=7-us cost=$5"},
+		{SubRule{Find: "{{([a-z]+)-(\\d)}}", Replace: "${2}-$1", Mode: ReplaceExpand}, "region=7-us cost=$5"},
+		{SubRule{Find: "{{([a-z]+)-(\\d)}}", Replace: "${2}-$1", Mode: ReplaceLiteral}, "region=${2}-$1 cost=$5"},
+		{SubRule{Find: "$5", Replace: "$$6", Mode: ReplaceLiteral}, "region=us-7 cost=$$6"},
+		{SubRule{Find: "{{(?P<area>[a-z]+)-(\\d)}}", Replace: `{{ .area | upper }}-{{ printf "%03s" .G2 }} ({{ .G0 }})`, Mode: ReplaceTemplate}, "region=US-007 (us-7) cost=$5"},
+		{SubRule{Find: "{{(?P<area>[a-z]+)-(\\d)}}", Replace: "{{ index .regions .area }}", Mode: ReplaceTemplate}, "region=United States cost=$5"},
+		{SubRule{Find: "{{([a-z]+)-(\\d)(x)?}}", Replace: "[{{ .G3 }}]", Mode: ReplaceTemplate}, "region=[] cost=$5"},
+		{SubRule{Find: "cost", Replace: "{{ .G0 | upper }}", Mode: ReplaceTemplate}, "region=us-7 COST=$5"},
+	}
+	for _, test := range tests {
+		rendered, _, err := renderUserRules(templateText, []SubRule{test.rule}, false)
+		if err != nil {
+			t.Errorf("[FAIL] renderUserRules returned error value for rule '%s': %v", test.rule, err)
+		}
+		if rendered != test.expected {
+			t.Errorf("[FAIL] Expected rendered template value = '%s' and received rendered template value '%s' for rule '%s'", test.expected, rendered, test.rule)
+		}
+	}
+
+	invalid := []string{"a=>{{ .G0 =>t", "a=>b=>lt", "a=>b=>q"}
+	for _, definition := range invalid {
+		if _, err := ParseSubRule(definition); err == nil {
+			t.Errorf("[FAIL] Expected ParseSubRule('%s') to return an error", definition)
+		}
+	}
+	if err := (SubRule{Find: "a", Mode: "bogus"}).Validate(); err == nil {
+		t.Errorf("[FAIL] Expected Validate() to return an error for an unsupported replacement mode")
+	}
+	if rule, _ := ParseSubRule("a=>b=>tt"); rule.Mode != ReplaceTemplate || rule.String() != "a=>b=>t" {
+		t.Errorf("[FAIL] Expected a template replacement mode rule, received %+v", rule)
+	}
+}
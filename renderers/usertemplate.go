@@ -37,21 +37,29 @@ import (
 // a rendered string using the findString string pointer replacement target substring with the
 // replaceString string pointer replacement substring
 func RenderFromLocalUserTemplate(templatePath string, findString *string, replaceString *string) (*string, error) {
+	renderedStringPointer, _, rendererr := RenderFromLocalUserTemplateWithRules(templatePath, []SubRule{{Find: *findString, Replace: *replaceString}}, false)
+	return renderedStringPointer, rendererr
+}
+
+// RenderFromLocalUserTemplateWithRules is a function that renders a text template file on the path templatePath to
+// a rendered string with the ordered substitution rules and returns the rendered string, the match report (nil when
+// withReport is false) and error
+func RenderFromLocalUserTemplateWithRules(templatePath string, rules []SubRule, withReport bool) (*string, *MatchReport, error) {
 	templateText, readerr := inkio.ReadFileToString(templatePath)
 	emptystring := "" // returned with errors
 
 	if readerr != nil {
 		responseReadErr := fmt.Errorf("unable to read local template file '%s'. %v", templatePath, readerr)
-		return &emptystring, responseReadErr
+		return &emptystring, nil, responseReadErr
 	}
 
-	renderedStringPointer, rendererr := renderUserTemplate(&templateText, findString, replaceString)
+	renderedString, report, rendererr := renderUserRules(templateText, rules, withReport)
 
 	if rendererr != nil {
 		renderErr := fmt.Errorf("unable to render local template file '%s'. %v", templatePath, rendererr)
-		return &emptystring, renderErr
+		return &emptystring, nil, renderErr
 	}
-	return renderedStringPointer, rendererr
+	return &renderedString, report, rendererr
 
 }
 
@@ -59,52 +67,47 @@ func RenderFromLocalUserTemplate(templatePath string, findString *string, replac
 // a rendered string using the findString string pointer replacement target substring with the
 // replaceString string pointer replacement substring
 func RenderFromRemoteUserTemplate(templateURL string, findString *string, replaceString *string) (*string, error) {
+	renderedStringPointer, _, rendererr := RenderFromRemoteUserTemplateWithRules(templateURL, []SubRule{{Find: *findString, Replace: *replaceString}}, false)
+	return renderedStringPointer, rendererr
+}
+
+// RenderFromRemoteUserTemplateWithRules is a function that renders a text template at the URL templateURL to a
+// rendered string with the ordered substitution rules and returns the rendered string, the match report (nil when
+// withReport is false) and error
+func RenderFromRemoteUserTemplateWithRules(templateURL string, rules []SubRule, withReport bool) (*string, *MatchReport, error) {
 	templateText, geterr := inkio.GetRequest(templateURL)
 	emptystring := "" // returned with errors
 
 	if geterr != nil {
 		responseReadErr := fmt.Errorf("unable to perform GET request for remote template file '%s'. %v", templateURL, geterr)
-		return &emptystring, responseReadErr
+		return &emptystring, nil, responseReadErr
 	}
 
-	renderedStringPointer, rendererr := renderUserTemplate(&templateText, findString, replaceString)
+	renderedString, report, rendererr := renderUserRules(templateText, rules, withReport)
 
 	if rendererr != nil {
 		renderErr := fmt.Errorf("unable to render remote template file '%s'. %v", templateURL, rendererr)
-		return &emptystring, renderErr
+		return &emptystring, nil, renderErr
 	}
-	return renderedStringPointer, rendererr
+	return &renderedString, report, rendererr
 }
 
-// renderUserTemplate is a function that performs the text string replacements in user templates across both
-// local and remote template files.  Supports string literal substitutions and regular expression substitutions.
-// The type of substitution performed is dependent upon the syntax of the user's --find= option definition
-func renderUserTemplate(templateText *string, findString *string, replaceString *string) (*string, error) {
-	emptystring := ""
-	re, reerr := regexp.Compile(`{{2}(.+)}{2}`) // regular expression to match command line {{regex}} syntax
-	if reerr != nil {
-		return &emptystring, reerr
-	}
-
+// CompileUserFind returns the compiled regular expression for a --find= option definition findString with the
+// {{regex}} syntax, or nil for a string literal findString.  The regular expression pattern is the text between the
+// opening {{ and the closing }} delimiters and may include any characters, including } characters and newlines
+func CompileUserFind(findString string) (*regexp.Regexp, error) {
 	// determine if user included {{regex}} syntax in --find= option
-	if strings.HasPrefix(*findString, "{{") && strings.HasSuffix(*findString, "}}") {
-		// attempt a match for the {{regex}} syntax and capture the regex pattern contained in the delimiters
-		if re.MatchString(*findString) {
-			userRegExSlice := re.FindStringSubmatch(*findString)
-			userRegExString := userRegExSlice[1]                    // define the user regular expression pattern at capture group position 1
-			userRegEx, userreerr := regexp.Compile(userRegExString) // compile a new regular expression with the user regex for text substitutions
-			if userreerr != nil {
-				return &emptystring, userreerr
-			}
-			regexOutString := userRegEx.ReplaceAllString(*templateText, *replaceString) // perform regex pattern matched replacements with the replacement string
-			return &regexOutString, nil
+	if isUserRegex(findString) {
+		userRegExString := findString[2 : len(findString)-2] // define the user regular expression pattern between the delimiters
+		if len(userRegExString) == 0 {
+			return nil, fmt.Errorf("failed to match a valid regular expression string with the {{regex}} syntax in the command")
 		}
-		regexMatchError := fmt.Errorf("failed to match a valid regular expression string with the {{regex}} syntax in the command")
-		return &emptystring, regexMatchError
+		return regexp.Compile(userRegExString)
 	}
+	return nil, nil
+}
 
-	// replace all instances of user specified template findString with user specified replaceString
-	renderedString := strings.Replace(*templateText, *findString, *replaceString, -1)
-
-	return &renderedString, nil
+// isUserRegex returns true when the --find= option definition findString uses the {{regex}} syntax
+func isUserRegex(findString string) bool {
+	return len(findString) >= 4 && strings.HasPrefix(findString, "{{") && strings.HasSuffix(findString, "}}")
 }
@@ -2,6 +2,7 @@ package renderers
 
 import (
 	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -58,6 +59,34 @@ func TestRenderRegexLocal(t *testing.T) {
 	}
 }
 
+func TestCompileUserFindDelimiters(t *testing.T) {
+	tests := []struct {
+		findstring string
+		pattern    string // expected regular expression pattern, empty for string literals
+	}{
+		{"{{\\d+}}", "\\d+"},
+		{"{{a}}b}}", "a}}b"}, // patterns may include the }} character combination
+		{"{{a\nb}}", "a\nb"}, // patterns may include newline characters
+		{"{{x{2}}}", "x{2}"}, // patterns may end with a } character
+		{"{{literal", ""},    // string literal without the closing delimiter
+		{"literal}}", ""},    // string literal without the opening delimiter
+	}
+	for _, testcase := range tests {
+		re, err := CompileUserFind(testcase.findstring)
+		if err != nil {
+			t.Errorf("[FAIL] CompileUserFind('%s') returned error value: %v", testcase.findstring, err)
+			continue
+		}
+		pattern := ""
+		if re != nil {
+			pattern = re.String()
+		}
+		if pattern != testcase.pattern {
+			t.Errorf("[FAIL] CompileUserFind('%s') expected pattern '%s', received '%s'", testcase.findstring, testcase.pattern, pattern)
+		}
+	}
+}
+
 func TestRenderRegexLocalBadRegex(t *testing.T) {
 	replacestring := "testing"
 	findstring := "{{}}" // try to use the regex definition syntax without a regex pattern
@@ -67,6 +96,57 @@ func TestRenderRegexLocalBadRegex(t *testing.T) {
 	}
 }
 
+func TestSubRuleCountMatches(t *testing.T) {
+	tests := []struct {
+		findstring string
+		expected   int
+	}{
+		{"1", 3},
+		{"{{[0-9]}}", 3},
+		{"{{\\d \\d}}", 1}, // matches do not overlap
+		{"[[user]]", 0},
+	}
+
+	for _, testcase := range tests {
+		matches, err := SubRule{Find: testcase.findstring}.CountMatches("This is a template with a few numbers 1 1 1")
+		if err != nil {
+			t.Errorf("[FAIL] SubRule.CountMatches execution returned error value: %v", err)
+		}
+		if matches != testcase.expected {
+			t.Errorf("[FAIL] Expected %d matches for find string '%s', received %d", testcase.expected, testcase.findstring, matches)
+		}
+	}
+
+	if _, err := (SubRule{Find: "{{[0-9}}"}).CountMatches("test"); err == nil {
+		t.Errorf("[FAIL] Expected error to be raised for invalid regular expression and the error value was 'nil'")
+	}
+}
+
+func TestRenderUserStrict(t *testing.T) {
+	Strict = true
+	defer func() { Strict = false }() // reset to default value or this interferes with other tests
+
+	templateText := "version 1.0.0"
+	replaceString := "2.0.0"
+	tests := []struct {
+		findString  string
+		expectError bool
+	}{
+		{"1.0.0", false},
+		{"3.0.0", true},
+		{"{{\\d+\\.\\d+\\.\\d+}}", false},
+		{"{{\\d+-\\d+}}", true},
+	}
+	for _, test := range tests {
+		_, _, err := renderUserRules(templateText, []SubRule{{Find: test.findString, Replace: replaceString}}, false)
+		if test.expectError && err == nil {
+			t.Errorf("[FAIL] Expected strict mode render error for --find definition '%s'", test.findString)
+		} else if !test.expectError && err != nil {
+			t.Errorf("[FAIL] Unexpected strict mode render error for --find definition '%s': %v", test.findString, err)
+		}
+	}
+}
+
 func TestRenderUserBadFilePathRaisesError(t *testing.T) {
 	replacestring := "testing"
 	findstring := "[[user]]"
@@ -76,6 +156,38 @@ func TestRenderUserBadFilePathRaisesError(t *testing.T) {
 	}
 }
 
+func TestRenderUserMatchReport(t *testing.T) {
+	tests := []struct {
+		templateText string
+		findString   string
+		replacement  string
+		lines        []int
+		changes      []LineChange
+	}{
+		{"a=1.0\nb=2\nc=1.0 1.0", "1.0", "2.0", []int{1, 3}, []LineChange{{1, "a=1.0", "a=2.0"}, {3, "c=1.0 1.0", "c=2.0 2.0"}}},
+		{"a=1.0\nb=2\n", "{{(\\d)\\.(\\d)}}", "$2.$1", []int{1}, []LineChange{{1, "a=1.0", "a=0.1"}}},
+		{"a\nb\nc", "{{a\\nb}}", "x", []int{1, 2}, []LineChange{{1, "a\nb", "x"}}},
+		{"a\nb\nc", "{{a\\n}}", "x", []int{1}, []LineChange{{1, "a\nb", "xb"}}},
+		{"a\nb\n", "{{a\\nb\\n}}", "x", []int{1, 2}, []LineChange{{1, "a\nb", "x"}}},
+		{"a\nb", "c", "x", nil, nil},
+	}
+	for _, test := range tests {
+		_, report, err := renderUserRules(test.templateText, []SubRule{{Find: test.findString, Replace: test.replacement}}, true)
+		if err != nil {
+			t.Errorf("[FAIL] Unexpected error for --find definition '%s': %v", test.findString, err)
+			continue
+		}
+		if !reflect.DeepEqual(report.Lines, test.lines) || !reflect.DeepEqual(report.Changes, test.changes) {
+			t.Errorf("[FAIL] Unexpected match report for --find definition '%s': %+v", test.findString, report)
+		}
+	}
+
+	templateText, findString, replacement := "a=1.0", "1.0", "2.0"
+	if _, report, _ := renderUserRules(templateText, []SubRule{{Find: findString, Replace: replacement}}, false); report != nil {
+		t.Errorf("[FAIL] Expected a nil match report without a report request, received %+v", report)
+	}
+}
+
 /*
 Remote user template tests
 */
@@ -0,0 +1,276 @@
+// diff holds the unified text diff functions for the ink application
+/*
+MIT License
+
+Copyright (c) 2017 Chris Simpkins
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package utilities
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContextLines is the number of unchanged context lines that surround changes in unified diff hunks
+const diffContextLines = 3
+
+// diffLine is a line in a line based edit script.  op is ' ' for unchanged lines, '-' for deleted lines, and '+' for
+// inserted lines
+type diffLine struct {
+	op     byte
+	text   string
+	oldIdx int // index of the line in the old text (unchanged and deleted lines)
+	newIdx int // index of the line in the new text (unchanged and inserted lines)
+}
+
+// UnifiedDiff returns the unified diff of the line changes from oldText (labeled oldName) to newText (labeled newName)
+// with three lines of context.  An empty string is returned when the texts are equal
+func UnifiedDiff(oldName string, newName string, oldText string, newText string) string {
+	if oldText == newText {
+		return ""
+	}
+	lines := diffLines(splitLines(oldText), splitLines(newText))
+
+	var diff strings.Builder
+	diff.WriteString("--- " + oldName + "\n")
+	diff.WriteString("+++ " + newName + "\n")
+	for start := 0; start < len(lines); {
+		// find the next changed line
+		for start < len(lines) && lines[start].op == ' ' {
+			start++
+		}
+		if start == len(lines) {
+			break
+		}
+		// extend the hunk until the unchanged lines between changes exceed twice the context line count
+		end := start
+		for i := start; i < len(lines); i++ {
+			if lines[i].op != ' ' {
+				end = i + 1
+			} else if i-end >= 2*diffContextLines {
+				break
+			}
+		}
+		hunkStart := start - diffContextLines
+		if hunkStart < 0 {
+			hunkStart = 0
+		}
+		hunkEnd := end + diffContextLines
+		if hunkEnd > len(lines) {
+			hunkEnd = len(lines)
+		}
+		writeHunk(&diff, lines[hunkStart:hunkEnd])
+		start = hunkEnd
+	}
+
+	return diff.String()
+}
+
+// writeHunk writes the hunk header and lines of a unified diff hunk to diff
+func writeHunk(diff *strings.Builder, hunk []diffLine) {
+	oldStart, newStart, oldCount, newCount := -1, -1, 0, 0
+	oldBefore, newBefore := 0, 0
+	for _, line := range hunk {
+		if line.op != '+' {
+			if oldStart < 0 {
+				oldStart = line.oldIdx + 1
+			}
+			oldCount++
+		} else if oldStart < 0 {
+			oldBefore = line.oldIdx
+		}
+		if line.op != '-' {
+			if newStart < 0 {
+				newStart = line.newIdx + 1
+			}
+			newCount++
+		} else if newStart < 0 {
+			newBefore = line.newIdx
+		}
+	}
+	if oldStart < 0 {
+		oldStart = oldBefore
+	}
+	if newStart < 0 {
+		newStart = newBefore
+	}
+
+	fmt.Fprintf(diff, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+	for _, line := range hunk {
+		diff.WriteByte(line.op)
+		diff.WriteString(line.text)
+		if !strings.HasSuffix(line.text, "\n") {
+			diff.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// splitLines splits text into lines that include the line ending newline characters
+func splitLines(text string) []string {
+	if len(text) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines returns the shortest line edit script from the lines a to the lines b with the linear space variant of
+// the Myers diff algorithm.  The texts are recursively divided at the middle snake of their shortest edit path so that
+// memory use grows with the line count and not with the line count times the edit distance
+func diffLines(a []string, b []string) []diffLine {
+	// compare integer line identifiers instead of line strings
+	ids := make(map[string]int)
+	lineIDs := func(lines []string) []int {
+		lineIDs := make([]int, len(lines))
+		for i, line := range lines {
+			id, ok := ids[line]
+			if !ok {
+				id = len(ids)
+				ids[line] = id
+			}
+			lineIDs[i] = id
+		}
+		return lineIDs
+	}
+	d := &differ{a: a, b: b, aIDs: lineIDs(a), bIDs: lineIDs(b)}
+	d.marks = make([]int, len(ids))
+	d.compare(0, len(a), 0, len(b))
+	return d.lines
+}
+
+// differ holds the state of a diffLines edit script search
+type differ struct {
+	a, b       []string // old and new lines
+	aIDs, bIDs []int    // line identifiers of a and b, equal lines have equal identifiers
+	marks      []int    // line identifier marks of the shared line search, indexed by line identifier
+	mark       int      // current shared line search mark
+	lines      []diffLine
+}
+
+// sharesLines returns true when a line of a[aLo:aHi] is also in b[bLo:bHi]
+func (d *differ) sharesLines(aLo int, aHi int, bLo int, bHi int) bool {
+	d.mark++
+	for _, id := range d.aIDs[aLo:aHi] {
+		d.marks[id] = d.mark
+	}
+	for _, id := range d.bIDs[bLo:bHi] {
+		if d.marks[id] == d.mark {
+			return true
+		}
+	}
+	return false
+}
+
+// compare appends the edit script from the lines a[aLo:aHi] to the lines b[bLo:bHi] to the differ lines
+func (d *differ) compare(aLo int, aHi int, bLo int, bHi int) {
+	// unchanged prefix and suffix lines
+	for aLo < aHi && bLo < bHi && d.aIDs[aLo] == d.bIDs[bLo] {
+		d.lines = append(d.lines, diffLine{' ', d.a[aLo], aLo, bLo})
+		aLo++
+		bLo++
+	}
+	suffix := 0
+	for aHi-suffix > aLo && bHi-suffix > bLo && d.aIDs[aHi-suffix-1] == d.bIDs[bHi-suffix-1] {
+		suffix++
+	}
+	aHi, bHi = aHi-suffix, bHi-suffix
+
+	switch {
+	case aLo == aHi || bLo == bHi || !d.sharesLines(aLo, aHi, bLo, bHi):
+		// without shared lines, the shortest edit script deletes all old lines and inserts all new lines
+		for x := aLo; x < aHi; x++ {
+			d.lines = append(d.lines, diffLine{'-', d.a[x], x, bLo})
+		}
+		for y := bLo; y < bHi; y++ {
+			d.lines = append(d.lines, diffLine{'+', d.b[y], aHi, y})
+		}
+	default:
+		// both ranges are changed and the edit distance is 2 or greater, the middle snake divides the search into
+		// two searches with smaller edit distances
+		x, y, u, v := d.middleSnake(aLo, aHi, bLo, bHi)
+		d.compare(aLo, x, bLo, y)
+		for ; x < u; x, y = x+1, y+1 {
+			d.lines = append(d.lines, diffLine{' ', d.a[x], x, y})
+		}
+		d.compare(u, aHi, v, bHi)
+	}
+
+	for i := 0; i < suffix; i++ {
+		d.lines = append(d.lines, diffLine{' ', d.a[aHi+i], aHi + i, bHi + i})
+	}
+}
+
+// middleSnake returns the start (x, y) and end (u, v) line indices of the middle snake of the shortest edit path from
+// the lines a[aLo:aHi] to the lines b[bLo:bHi].  The furthest reaching forward and reverse paths are searched
+// simultaneously until they overlap
+func (d *differ) middleSnake(aLo int, aHi int, bLo int, bHi int) (int, int, int, int) {
+	n, m := aHi-aLo, bHi-bLo
+	delta := n - m
+	odd := delta%2 != 0
+	maxD := (n + m + 1) / 2
+	offset := maxD + 1
+	forward := make([]int, 2*offset+1) // furthest x on the forward diagonals k = x - y
+	reverse := make([]int, 2*offset+1) // furthest x from the end of a on the reverse diagonals
+
+	for step := 0; step <= maxD; step++ {
+		for k := -step; k <= step; k += 2 {
+			var x int
+			if k == -step || (k != step && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1] // insertion
+			} else {
+				x = forward[offset+k-1] + 1 // deletion
+			}
+			y := x - k
+			startX, startY := x, y
+			for x < n && y < m && d.aIDs[aLo+x] == d.bIDs[bLo+y] {
+				x++
+				y++
+			}
+			forward[offset+k] = x
+			if reverseK := delta - k; odd && reverseK >= -(step-1) && reverseK <= step-1 && x+reverse[offset+reverseK] >= n {
+				return aLo + startX, bLo + startY, aLo + x, bLo + y
+			}
+		}
+		for k := -step; k <= step; k += 2 {
+			var x int
+			if k == -step || (k != step && reverse[offset+k-1] < reverse[offset+k+1]) {
+				x = reverse[offset+k+1]
+			} else {
+				x = reverse[offset+k-1] + 1
+			}
+			y := x - k
+			startX, startY := x, y
+			for x < n && y < m && d.aIDs[aHi-x-1] == d.bIDs[bHi-y-1] {
+				x++
+				y++
+			}
+			reverse[offset+k] = x
+			if forwardK := delta - k; !odd && forwardK >= -step && forwardK <= step && x+forward[offset+forwardK] >= n {
+				return aHi - x, bHi - y, aHi - startX, bHi - startY
+			}
+		}
+	}
+	return aLo, bLo, aLo, bLo // unreachable, the paths overlap at the latest after half of the edit distance
+}
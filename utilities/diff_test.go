@@ -0,0 +1,129 @@
+package utilities
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiffEqualTexts(t *testing.T) {
+	if diff := UnifiedDiff("a", "b", "one\ntwo\n", "one\ntwo\n"); diff != "" {
+		t.Errorf("[FAIL] Expected UnifiedDiff to return an empty string for equal texts, received '%s'", diff)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		oldtext  string
+		newtext  string
+		expected string
+	}{
+		{
+			"one\ntwo\nthree\n",
+			"one\n2\nthree\n",
+			"--- a\n+++ b\n@@ -1,3 +1,3 @@\n one\n-two\n+2\n three\n",
+		},
+		{
+			"",
+			"one\n",
+			"--- a\n+++ b\n@@ -0,0 +1,1 @@\n+one\n",
+		},
+		{
+			"one\n",
+			"",
+			"--- a\n+++ b\n@@ -1,1 +0,0 @@\n-one\n",
+		},
+		{
+			"sha=abcd123",
+			"sha=饂饂饂饂",
+			"--- a\n+++ b\n@@ -1,1 +1,1 @@\n-sha=abcd123\n\\ No newline at end of file\n+sha=饂饂饂饂\n\\ No newline at end of file\n",
+		},
+		{
+			"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			"one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n",
+			"--- a\n+++ b\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+twelve\n",
+		},
+		{
+			"1\n2\n3\n4\n5\n6\n7\n",
+			"1\n2\n3\nfour\n5\n6\n7\n8\n",
+			"--- a\n+++ b\n@@ -1,7 +1,8 @@\n 1\n 2\n 3\n-4\n+four\n 5\n 6\n 7\n+8\n",
+		},
+	}
+
+	for _, testcase := range tests {
+		diff := UnifiedDiff("a", "b", testcase.oldtext, testcase.newtext)
+		if diff != testcase.expected {
+			t.Errorf("[FAIL] Expected UnifiedDiff to return:\n%s\nreceived:\n%s", testcase.expected, diff)
+		}
+	}
+}
+
+func TestDiffLinesShortestEditScript(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	randomLines := func() []string {
+		lines := make([]string, random.Intn(30))
+		for i := range lines {
+			lines[i] = strconv.Itoa(random.Intn(4)) + "\n"
+		}
+		return lines
+	}
+	for i := 0; i < 500; i++ {
+		a, b := randomLines(), randomLines()
+		lines := diffLines(a, b)
+		var oldLines, newLines []string
+		edits := 0
+		for _, line := range lines {
+			if line.op != '+' {
+				if a[line.oldIdx] != line.text || line.oldIdx != len(oldLines) {
+					t.Fatalf("[FAIL] Unexpected old line %+v in the edit script of %q -> %q", line, a, b)
+				}
+				oldLines = append(oldLines, line.text)
+			}
+			if line.op != '-' {
+				if b[line.newIdx] != line.text || line.newIdx != len(newLines) {
+					t.Fatalf("[FAIL] Unexpected new line %+v in the edit script of %q -> %q", line, a, b)
+				}
+				newLines = append(newLines, line.text)
+			}
+			if line.op != ' ' {
+				edits++
+			}
+		}
+		if len(oldLines) != len(a) || len(newLines) != len(b) {
+			t.Fatalf("[FAIL] Expected the edit script of %q -> %q to include all lines, received %+v", a, b, lines)
+		}
+		// the shortest edit script deletes and inserts the lines that are not in the longest common subsequence
+		lcs := make([][]int, len(a)+1)
+		for x := range lcs {
+			lcs[x] = make([]int, len(b)+1)
+		}
+		for x := len(a) - 1; x >= 0; x-- {
+			for y := len(b) - 1; y >= 0; y-- {
+				if a[x] == b[y] {
+					lcs[x][y] = lcs[x+1][y+1] + 1
+				} else if lcs[x+1][y] > lcs[x][y+1] {
+					lcs[x][y] = lcs[x+1][y]
+				} else {
+					lcs[x][y] = lcs[x][y+1]
+				}
+			}
+		}
+		if expected := len(a) + len(b) - 2*lcs[0][0]; edits != expected {
+			t.Fatalf("[FAIL] Expected %d edits from %q to %q, received %d", expected, a, b, edits)
+		}
+	}
+}
+
+func TestUnifiedDiffLargeChangedText(t *testing.T) {
+	var oldText, newText strings.Builder
+	for i := 0; i < 20000; i++ {
+		fmt.Fprintf(&oldText, "old line %d\n", i)
+		fmt.Fprintf(&newText, "new line %d\n", i)
+	}
+	diff := UnifiedDiff("a", "b", oldText.String(), newText.String())
+	if !strings.HasPrefix(diff, "--- a\n+++ b\n@@ -1,20000 +1,20000 @@\n-old line 0\n") || strings.Count(diff, "\n") != 40003 {
+		t.Errorf("[FAIL] Expected a single hunk that replaces all 20000 lines, received a %d line diff", strings.Count(diff, "\n"))
+	}
+}
@@ -0,0 +1,143 @@
+// dir holds the template directory search functions for the ink application
+/*
+MIT License
+
+Copyright (c) 2017 Chris Simpkins
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package utilities
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/chrissimpkins/ink/validators"
+)
+
+// FindTemplates recursively searches the directory dirPath and returns the file paths of all templates with the
+// *.in file extension.  When include glob patterns are defined, the template file name or the file path relative
+// to dirPath must match at least one of them.  Files and directories that match an exclude glob pattern are skipped.
+// Symbolic links are followed when followSymlinks is true and skipped otherwise
+func FindTemplates(dirPath string, includes []string, excludes []string, followSymlinks bool) ([]string, error) {
+	return findFiles(dirPath, includes, excludes, followSymlinks, false)
+}
+
+// FindFiles recursively searches the directory dirPath and returns the file paths of all files, irrespective of the
+// file extension, that match the include glob patterns (all files when undefined).  Exclude glob patterns and symbolic
+// links are handled as in FindTemplates.  This is used to find the files that are edited in --in-place mode
+func FindFiles(dirPath string, includes []string, excludes []string, followSymlinks bool) ([]string, error) {
+	return findFiles(dirPath, includes, excludes, followSymlinks, true)
+}
+
+// findFiles searches the directory dirPath for templates with the *.in file extension, or for files with any
+// extension when anyExtension is true, and returns the sorted file paths
+func findFiles(dirPath string, includes []string, excludes []string, followSymlinks bool, anyExtension bool) ([]string, error) {
+	var templatePaths []string
+	visited := make(map[string]bool) // resolved directory paths, used to prevent symbolic link loops
+	err := findTemplates(dirPath, "", includes, excludes, followSymlinks, anyExtension, visited, &templatePaths)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(templatePaths)
+	return templatePaths, nil
+}
+
+// findTemplates searches the directory at root + relDir and appends the template paths to templatePaths
+func findTemplates(root string, relDir string, includes []string, excludes []string, followSymlinks bool, anyExtension bool, visited map[string]bool, templatePaths *[]string) error {
+	dirPath := filepath.Join(root, relDir)
+	realPath, evalerr := filepath.EvalSymlinks(dirPath)
+	if evalerr != nil {
+		return evalerr
+	}
+	if visited[realPath] {
+		return nil
+	}
+	visited[realPath] = true
+
+	fileInfos, readerr := ioutil.ReadDir(dirPath)
+	if readerr != nil {
+		return readerr
+	}
+	for _, fileInfo := range fileInfos {
+		relPath := filepath.Join(relDir, fileInfo.Name())
+		if MatchesGlob(relPath, excludes) {
+			continue
+		}
+		if fileInfo.Mode()&os.ModeSymlink != 0 {
+			if !followSymlinks {
+				continue
+			}
+			var staterr error
+			fileInfo, staterr = os.Stat(filepath.Join(root, relPath))
+			if staterr != nil {
+				return staterr
+			}
+		}
+		if fileInfo.IsDir() {
+			if finderr := findTemplates(root, relPath, includes, excludes, followSymlinks, anyExtension, visited, templatePaths); finderr != nil {
+				return finderr
+			}
+			continue
+		}
+		if (anyExtension || validators.HasCorrectExtension(relPath)) && (len(includes) == 0 || MatchesGlob(relPath, includes)) {
+			*templatePaths = append(*templatePaths, filepath.Join(root, relPath))
+		}
+	}
+	return nil
+}
+
+// MirroredParentDir is the directory name that parent directory (..) elements of template paths are mirrored to below
+// an output directory, so that "../src/a.txt.in" and "src/a.txt.in" are not rendered to the same outfile
+const MirroredParentDir = "__parent__"
+
+// MirroredPath returns the relative file path that mirrors filePath below an output directory.  The volume name and
+// root directory are removed from the path, and parent directory (..) elements are replaced with MirroredParentDir
+func MirroredPath(filePath string) string {
+	filePath = filepath.Clean(filePath)
+	filePath = strings.TrimPrefix(filePath, filepath.VolumeName(filePath))
+	var elements []string
+	for _, element := range strings.Split(filepath.ToSlash(filePath), "/") {
+		switch element {
+		case "", ".":
+		case "..":
+			elements = append(elements, MirroredParentDir)
+		default:
+			elements = append(elements, element)
+		}
+	}
+	return filepath.Join(elements...)
+}
+
+// MatchesGlob returns true when the file name or the relative file path relPath matches any of the glob patterns
+func MatchesGlob(relPath string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := filepath.Match(pattern, filepath.Base(relPath)); matched {
+			return true
+		}
+		if matched, _ := filepath.Match(filepath.FromSlash(pattern), relPath); matched {
+			return true
+		}
+	}
+	return false
+}
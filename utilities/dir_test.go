@@ -0,0 +1,105 @@
+package utilities
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFindTemplates(t *testing.T) {
+	dirPath := filepath.Join("..", "testfiles", "dir")
+	tests := []struct {
+		includes []string
+		excludes []string
+		expected []string
+	}{
+		{nil, nil, []string{"a.txt.in", filepath.Join("skip", "d.txt.in"), filepath.Join("sub", "b.txt.in"), filepath.Join("sub", "c.md.in")}},
+		{nil, []string{"skip"}, []string{"a.txt.in", filepath.Join("sub", "b.txt.in"), filepath.Join("sub", "c.md.in")}},
+		{[]string{"*.txt.in"}, []string{"skip"}, []string{"a.txt.in", filepath.Join("sub", "b.txt.in")}},
+		{[]string{"sub/*"}, nil, []string{filepath.Join("sub", "b.txt.in"), filepath.Join("sub", "c.md.in")}},
+	}
+
+	for _, testcase := range tests {
+		response, err := FindTemplates(dirPath, testcase.includes, testcase.excludes, false)
+		if err != nil {
+			t.Errorf("[FAIL] Did not expect error returned from FindTemplates, received: %v", err)
+		}
+		var expected []string
+		for _, relPath := range testcase.expected {
+			expected = append(expected, filepath.Join(dirPath, relPath))
+		}
+		if strings.Join(response, ",") != strings.Join(expected, ",") {
+			t.Errorf("[FAIL] Expected FindTemplates to return %v, received: %v", expected, response)
+		}
+	}
+}
+
+func TestFindTemplatesSymlinks(t *testing.T) {
+	tempDir, tempErr := ioutil.TempDir("", "ink")
+	if tempErr != nil {
+		t.Fatalf("[FAIL] Unable to create temporary directory: %v", tempErr)
+	}
+	defer os.RemoveAll(tempDir)
+	target, _ := filepath.Abs(filepath.Join("..", "testfiles", "dir", "sub"))
+	if linkErr := os.Symlink(target, filepath.Join(tempDir, "linked")); linkErr != nil {
+		t.Skipf("symbolic links are not supported on this platform: %v", linkErr)
+	}
+	if linkErr := os.Symlink(tempDir, filepath.Join(tempDir, "loop")); linkErr != nil {
+		t.Skipf("symbolic links are not supported on this platform: %v", linkErr)
+	}
+
+	response, err := FindTemplates(tempDir, nil, nil, false)
+	if err != nil || len(response) != 0 {
+		t.Errorf("[FAIL] Expected FindTemplates to skip symbolic links, received: %v %v", response, err)
+	}
+	response, err = FindTemplates(tempDir, nil, nil, true)
+	if err != nil || len(response) != 2 {
+		t.Errorf("[FAIL] Expected FindTemplates to return 2 templates through followed symbolic links, received: %v %v", response, err)
+	}
+}
+
+func TestFindFiles(t *testing.T) {
+	dirPath := filepath.Join("..", "testfiles", "dir")
+	response, err := FindFiles(dirPath, []string{"*.txt", "sub/*"}, nil, false)
+	if err != nil {
+		t.Errorf("[FAIL] Did not expect error returned from FindFiles, received: %v", err)
+	}
+	expected := []string{filepath.Join(dirPath, "notes.txt"), filepath.Join(dirPath, "sub", "b.txt.in"), filepath.Join(dirPath, "sub", "c.md.in")}
+	if strings.Join(response, ",") != strings.Join(expected, ",") {
+		t.Errorf("[FAIL] Expected FindFiles to return %v, received: %v", expected, response)
+	}
+}
+
+func TestFindTemplatesMissingDirectory(t *testing.T) {
+	_, err := FindTemplates(filepath.Join("..", "testfiles", "totallybogus"), nil, nil, false)
+	if err == nil {
+		t.Errorf("[FAIL] Expected FindTemplates to return an error for a missing directory, received nil")
+	}
+}
+
+func TestMirroredPath(t *testing.T) {
+	tests := []struct {
+		filepath string
+		expected string
+	}{
+		{filepath.Join("templates", "a.txt.in"), filepath.Join("templates", "a.txt.in")},
+		{filepath.Join(".", "templates", "a.txt.in"), filepath.Join("templates", "a.txt.in")},
+		{filepath.Join("..", "..", "templates", "a.txt.in"), filepath.Join("__parent__", "__parent__", "templates", "a.txt.in")},
+		{filepath.Join("templates", "..", "..", "a.txt.in"), filepath.Join("__parent__", "a.txt.in")},
+		{string(filepath.Separator) + filepath.Join("srv", "templates", "a.txt.in"), filepath.Join("srv", "templates", "a.txt.in")},
+	}
+
+	for _, testcase := range tests {
+		response := MirroredPath(testcase.filepath)
+		if response != testcase.expected {
+			t.Errorf("[FAIL] Expected MirroredPath to return '%s' for '%s', received: '%s'", testcase.expected, testcase.filepath, response)
+		}
+	}
+
+	// templates inside and outside of the working directory are not mirrored to the same path
+	if MirroredPath(filepath.Join("..", "src", "a.txt.in")) == MirroredPath(filepath.Join("src", "a.txt.in")) {
+		t.Errorf("[FAIL] Expected MirroredPath to return different paths for '../src/a.txt.in' and 'src/a.txt.in'")
+	}
+}
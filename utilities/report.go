@@ -0,0 +1,183 @@
+// report holds the machine-readable lint and render result formats for the ink application
+/*
+MIT License
+
+Copyright (c) 2017 Chris Simpkins
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package utilities
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/chrissimpkins/ink/renderers"
+	"github.com/chrissimpkins/ink/validators"
+)
+
+// Record is the structured lint or render result for a single template in --format=json output
+type Record struct {
+	Template    string                  `json:"template"`         // template file path or URL
+	Status      string                  `json:"status"`           // e.g. "rendered", "unchanged", "failed", "valid", "invalid"
+	Output      string                  `json:"output,omitempty"` // rendered file path, empty for lints and standard output stream renders
+	DurationMS  float64                 `json:"duration_ms"`
+	Error       *RecordError            `json:"error,omitempty"`
+	Diagnostics []validators.Diagnostic `json:"diagnostics,omitempty"` // lint diagnostics
+	Matches     *int                    `json:"matches,omitempty"`     // --find= value matches in user-defined template lints and --report renders
+	Lines       []int                   `json:"lines,omitempty"`       // --report template line numbers with --find= value matches
+	Changes     []renderers.LineChange  `json:"changes,omitempty"`     // --report-preview changed template lines
+}
+
+// RecordError is an error in a Record with the template line and column numbers when they are available (0 = unknown)
+type RecordError struct {
+	Message string `json:"message"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+}
+
+// templateErrorPosition matches the line and optional column numbers in text/template parse and execution error
+// messages (e.g. `template: ink:3: unexpected "}" in operand`, `template: ink:1:11: executing "ink" at <.Foo>: ...`)
+// that may be wrapped in other error messages
+var templateErrorPosition = regexp.MustCompile(`template: .*?:(\d+)(?::(\d+))?: `)
+
+// NewRecord returns a Record for the template path or URL templatePath.  A non-nil err is included in the Record with
+// the template line and column numbers that are parsed from text/template error messages
+func NewRecord(templatePath string, status string, outPath string, duration time.Duration, err error) Record {
+	record := Record{
+		Template:   templatePath,
+		Status:     status,
+		Output:     outPath,
+		DurationMS: float64(duration.Microseconds()) / 1000,
+	}
+	if err != nil {
+		record.Error = &RecordError{Message: err.Error()}
+		if match := templateErrorPosition.FindStringSubmatch(err.Error()); match != nil {
+			record.Error.Line, _ = strconv.Atoi(match[1])
+			record.Error.Column, _ = strconv.Atoi(match[2])
+		}
+	}
+	return record
+}
+
+// WriteJSONRecord writes record to w as a single line of JSON
+func WriteJSONRecord(w io.Writer, record Record) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(record)
+}
+
+// SARIF 2.1.0 log types, see https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string `json:"name"`
+	Version        string `json:"version"`
+	InformationURI string `json:"informationUri"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// SARIFLog returns a SARIF 2.1.0 log for the lint records with one result for each lint diagnostic and for each
+// record error.  toolVersion is the ink application version string
+func SARIFLog(records []Record, toolVersion string) ([]byte, error) {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "ink",
+			Version:        toolVersion,
+			InformationURI: "https://github.com/chrissimpkins/ink",
+		}},
+		Results: []sarifResult{},
+	}
+	for _, record := range records {
+		if record.Error != nil {
+			run.Results = append(run.Results, newSARIFResult(record.Template, "read-error", validators.SeverityError, record.Error.Message, record.Error.Line, record.Error.Column))
+		}
+		for _, diagnostic := range record.Diagnostics {
+			run.Results = append(run.Results, newSARIFResult(record.Template, diagnostic.Rule, diagnostic.Severity, diagnostic.Message, diagnostic.Line, diagnostic.Column))
+		}
+	}
+	return json.MarshalIndent(sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{run},
+	}, "", "  ")
+}
+
+// newSARIFResult returns a SARIF result for a finding in the template templatePath.  The level is the diagnostic
+// severity ("error", "warning") and the region is omitted when the line is not known
+func newSARIFResult(templatePath string, ruleID string, level string, message string, line int, column int) sarifResult {
+	location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+		ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(templatePath)},
+	}}
+	if line > 0 {
+		location.PhysicalLocation.Region = &sarifRegion{StartLine: line, StartColumn: column}
+	}
+	return sarifResult{
+		RuleID:    ruleID,
+		Level:     level,
+		Message:   sarifMessage{Text: message},
+		Locations: []sarifLocation{location},
+	}
+}
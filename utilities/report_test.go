@@ -0,0 +1,94 @@
+package utilities
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chrissimpkins/ink/validators"
+)
+
+func TestNewRecordErrorPosition(t *testing.T) {
+	tests := []struct {
+		err    error
+		line   int
+		column int
+	}{
+		{errors.New(`template: ink:3: unexpected "}" in operand`), 3, 0},
+		{errors.New(`template: ink:1:11: executing "ink" at <.Foo>: can't evaluate field Foo`), 1, 11},
+		{errors.New(`unable to render local template file 'a.txt.in'. template: ink:2:5: executing "ink" at <.Foo>: nil`), 2, 5},
+		{errors.New(`template: https://test.com:8080/a.txt.in:4: unclosed action`), 4, 0},
+		{errors.New(`open a.txt.in: no such file or directory`), 0, 0},
+	}
+
+	for _, testcase := range tests {
+		record := NewRecord("a.txt.in", "failed", "a.txt", time.Millisecond, testcase.err)
+		if record.Error == nil {
+			t.Errorf("[FAIL] Expected a record error for the error '%v'", testcase.err)
+			continue
+		}
+		if record.Error.Line != testcase.line || record.Error.Column != testcase.column {
+			t.Errorf("[FAIL] Expected line %d and column %d for the error '%v', received line %d and column %d", testcase.line, testcase.column, testcase.err, record.Error.Line, record.Error.Column)
+		}
+	}
+	if record := NewRecord("a.txt.in", "rendered", "a.txt", time.Millisecond, nil); record.Error != nil {
+		t.Errorf("[FAIL] Expected a nil record error without an error, received: %v", record.Error)
+	}
+}
+
+func TestWriteJSONRecord(t *testing.T) {
+	var buffer bytes.Buffer
+	record := NewRecord("a.txt.in", "failed", "a.txt", 1500*time.Microsecond, errors.New("template: ink:1:7: executing \"ink\" at <.Foo>: nil"))
+	if writeerr := WriteJSONRecord(&buffer, record); writeerr != nil {
+		t.Errorf("[FAIL] Unexpected error returned from WriteJSONRecord: %v", writeerr)
+	}
+	expected := `{"template":"a.txt.in","status":"failed","output":"a.txt","duration_ms":1.5,"error":{"message":"template: ink:1:7: executing \"ink\" at <.Foo>: nil","line":1,"column":7}}` + "\n"
+	if buffer.String() != expected {
+		t.Errorf("[FAIL] Expected JSON record '%s', received '%s'", expected, buffer.String())
+	}
+}
+
+func TestSARIFLog(t *testing.T) {
+	invalidRecord := NewRecord("invalid.txt.in", "invalid", "", time.Millisecond, nil)
+	invalidRecord.Diagnostics = []validators.Diagnostic{
+		{Line: 2, Column: 5, Severity: validators.SeverityError, Rule: validators.RuleUnknownFunction, Message: `function "bogus" is not defined`},
+		{Line: 1, Column: 1, Severity: validators.SeverityWarning, Rule: validators.RuleNoTokens, Message: "template does not contain template tokens"},
+	}
+	records := []Record{
+		NewRecord("good.txt.in", "valid", "", time.Millisecond, nil),
+		NewRecord("bad.txt.in", "invalid", "", time.Millisecond, errors.New("template: ink:3: unclosed action")),
+		invalidRecord,
+	}
+	sarif, sariferr := SARIFLog(records, "0.7.2")
+	if sariferr != nil {
+		t.Errorf("[FAIL] Unexpected error returned from SARIFLog: %v", sariferr)
+	}
+	var log sarifLog
+	if jsonerr := json.Unmarshal(sarif, &log); jsonerr != nil {
+		t.Fatalf("[FAIL] SARIFLog returned invalid JSON: %v", jsonerr)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || log.Runs[0].Tool.Driver.Version != "0.7.2" {
+		t.Errorf("[FAIL] Unexpected SARIF log header: %s", sarif)
+	}
+	results := log.Runs[0].Results
+	if len(results) != 3 {
+		t.Fatalf("[FAIL] Expected three SARIF results, received %d", len(results))
+	}
+	location := results[0].Locations[0].PhysicalLocation
+	if location.ArtifactLocation.URI != "bad.txt.in" || location.Region == nil || location.Region.StartLine != 3 {
+		t.Errorf("[FAIL] Unexpected SARIF result location: %s", sarif)
+	}
+	if !strings.Contains(results[0].Message.Text, "unclosed action") {
+		t.Errorf("[FAIL] Unexpected SARIF result message: %s", results[0].Message.Text)
+	}
+	diagnosticResult := results[1]
+	if diagnosticResult.RuleID != "unknown-function" || diagnosticResult.Level != "error" || diagnosticResult.Locations[0].PhysicalLocation.Region.StartColumn != 5 {
+		t.Errorf("[FAIL] Unexpected SARIF result for a lint diagnostic: %s", sarif)
+	}
+	if results[2].Level != "warning" {
+		t.Errorf("[FAIL] Expected SARIF warning level for a lint warning diagnostic, received %s", results[2].Level)
+	}
+}
@@ -2,6 +2,7 @@ package utilities
 
 import (
 	"net/url"
+	"path/filepath"
 	"strings"
 )
 
@@ -17,3 +18,12 @@ func GetURLFilePath(URL string) (string, error) {
 	}
 	return u.Path, err
 }
+
+// GetURLMirroredPath parses a URL string and returns the relative file path that mirrors the URL path and error
+func GetURLMirroredPath(URL string) (string, error) {
+	u, err := url.Parse(URL)
+	if err != nil {
+		return "", err
+	}
+	return MirroredPath(filepath.FromSlash(u.Path)), nil
+}
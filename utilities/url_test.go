@@ -1,6 +1,9 @@
 package utilities
 
-import "testing"
+import (
+	"path/filepath"
+	"testing"
+)
 
 func TestGetURLFilePathInkTemplateDepthOne(t *testing.T) {
 	response, err := GetURLFilePath("http://test.com/inktemplate.txt.in")
@@ -73,3 +76,13 @@ func TestGetURLFilePathInkTemplateNoProtocolOrDirs(t *testing.T) {
 		t.Errorf("[FAIL] Expected return of path 'inktemplate.txt.in', received: %s", response)
 	}
 }
+
+func TestGetURLMirroredPath(t *testing.T) {
+	response, err := GetURLMirroredPath("http://test.com/testing/more/inktemplate.txt.in")
+	if err != nil {
+		t.Errorf("[FAIL] Did not expect error returned from GetURLMirroredPath for valid file, received: %v", err)
+	}
+	if response != filepath.Join("testing", "more", "inktemplate.txt.in") {
+		t.Errorf("[FAIL] Expected return of path 'testing/more/inktemplate.txt.in', received: %s", response)
+	}
+}
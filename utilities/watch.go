@@ -0,0 +1,71 @@
+// watch holds the file change detection functions for the ink application
+/*
+MIT License
+
+Copyright (c) 2017 Chris Simpkins
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package utilities
+
+import (
+	"os"
+	"sort"
+	"time"
+)
+
+// FileStamp records the modification state of a file for change detection by polling
+type FileStamp struct {
+	Exists  bool
+	ModTime time.Time
+	Size    int64
+}
+
+// StatFiles returns the current FileStamp of each file path in paths.  Missing files receive a FileStamp with
+// Exists == false so that file creation is detected as a change
+func StatFiles(paths []string) map[string]FileStamp {
+	stamps := make(map[string]FileStamp, len(paths))
+	for _, path := range paths {
+		fileInfo, staterr := os.Stat(path)
+		if staterr != nil {
+			stamps[path] = FileStamp{}
+			continue
+		}
+		stamps[path] = FileStamp{Exists: true, ModTime: fileInfo.ModTime(), Size: fileInfo.Size()}
+	}
+	return stamps
+}
+
+// ChangedFiles returns the sorted file paths in current that have a FileStamp that differs from the FileStamp of the
+// same file path in previous.  File paths that are not in previous are not reported as changed
+func ChangedFiles(previous map[string]FileStamp, current map[string]FileStamp) []string {
+	var changed []string
+	for path, stamp := range current {
+		previousStamp, ok := previous[path]
+		if !ok {
+			continue
+		}
+		if stamp.Exists != previousStamp.Exists || stamp.Size != previousStamp.Size || !stamp.ModTime.Equal(previousStamp.ModTime) {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
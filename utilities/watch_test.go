@@ -0,0 +1,46 @@
+package utilities
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChangedFiles(t *testing.T) {
+	tempDir, tempErr := ioutil.TempDir("", "ink")
+	if tempErr != nil {
+		t.Fatalf("[FAIL] Unable to create temporary directory: %v", tempErr)
+	}
+	defer os.RemoveAll(tempDir)
+	modifiedPath := filepath.Join(tempDir, "modified.txt.in")
+	unchangedPath := filepath.Join(tempDir, "unchanged.txt.in")
+	createdPath := filepath.Join(tempDir, "created.txt.in")
+	removedPath := filepath.Join(tempDir, "removed.txt.in")
+	mockModTime := time.Date(2017, 10, 18, 0, 0, 0, 0, time.UTC)
+	for _, path := range []string{modifiedPath, unchangedPath, removedPath} {
+		ioutil.WriteFile(path, []byte("test"), 0644)
+		os.Chtimes(path, mockModTime, mockModTime)
+	}
+	paths := []string{modifiedPath, unchangedPath, createdPath, removedPath}
+
+	previous := StatFiles(paths)
+	if previous[createdPath].Exists || !previous[modifiedPath].Exists {
+		t.Errorf("[FAIL] Expected StatFiles to report file existence, received: %v", previous)
+	}
+	if changed := ChangedFiles(previous, StatFiles(paths)); len(changed) != 0 {
+		t.Errorf("[FAIL] Expected no changed files without file modifications, received: %v", changed)
+	}
+
+	ioutil.WriteFile(modifiedPath, []byte("test"), 0644)
+	os.Chtimes(modifiedPath, mockModTime.Add(time.Second), mockModTime.Add(time.Second))
+	ioutil.WriteFile(createdPath, []byte("test"), 0644)
+	os.Remove(removedPath)
+	expected := []string{createdPath, modifiedPath, removedPath}
+	changed := ChangedFiles(previous, StatFiles(append(paths, filepath.Join(tempDir, "new.txt.in"))))
+	if strings.Join(changed, ",") != strings.Join(expected, ",") {
+		t.Errorf("[FAIL] Expected ChangedFiles to return %v, received: %v", expected, changed)
+	}
+}
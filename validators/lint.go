@@ -26,26 +26,429 @@ SOFTWARE.
 package validators
 
 import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"text/template"
+	"text/template/parse"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/chrissimpkins/ink/inkio"
+	"github.com/chrissimpkins/ink/renderers"
 )
 
-// LintTemplateSuccess is an ink template linting function for file on path filePath that returns (success = bool, error) response
+// Diagnostic severity levels.  Templates with error diagnostics fail the lint, warnings are reported only
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Lint rule IDs
+const (
+	RuleParseError           = "parse-error"           // text/template syntax error
+	RuleUnknownFunction      = "unknown-function"      // call of a function that is not an ink template function
+	RuleUnbalancedDelimiters = "unbalanced-delimiters" // unclosed {{ action or stray }} delimiter
+	RuleUnknownField         = "unknown-field"         // reference to a field that is not a ReplacementStrings field or .Env
+	RuleTrailingWhitespace   = "trailing-whitespace"   // more whitespace before the closing delimiter than after the opening delimiter
+	RuleNoTokens             = "no-tokens"             // template without template actions
+	RuleMissingInclude       = "missing-include"       // {{ include "path" }} of a template that does not exist
+	RuleFrontMatter          = "front-matter"          // invalid front matter block setting
+
+	// user-defined template rules for --find= option renders
+	RuleInvalidFind = "invalid-find" // --find= {{regex}} pattern that does not compile
+	RuleNoMatches   = "no-matches"   // --find= value without matches in the template
+)
+
+// Diagnostic is a template lint finding.  Line and Column are 1-based template text positions (0 = unknown), columns
+// are counted in characters
+type Diagnostic struct {
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Severity string `json:"severity"`
+	Rule     string `json:"rule"`
+	Message  string `json:"message"`
+}
+
+// String returns the diagnostic in `line:column: severity: message [rule]` format
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%d:%d: %s: %s [%s]", d.Line, d.Column, d.Severity, d.Message, d.Rule)
+}
+
+// HasErrors returns true if diagnostics include a diagnostic with error severity
+func HasErrors(diagnostics []Diagnostic) bool {
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// LintTemplateSuccess is an ink template linting function for file on path or URL filePath that returns (success = bool, error) response.
+// The error response describes the first error diagnostic of a failed lint
 func LintTemplateSuccess(filePath string) (bool, error) {
-	templateText, readerr := inkio.ReadFileToString(filePath)
+	diagnostics, linterr := LintTemplate(filePath)
+	if linterr != nil {
+		return false, linterr
+	}
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == SeverityError {
+			return false, errors.New(diagnostic.String())
+		}
+	}
+	return true, nil
+}
+
+// LintTemplate lints the builtin template file on path filePath, or the remote template on URL filePath, and returns
+// the diagnostics sorted by position.  The error response is reserved for templates that cannot be read
+func LintTemplate(filePath string) ([]Diagnostic, error) {
+	templateText, readerr := inkio.ReadPathOrURL(filePath)
 	if readerr != nil {
-		return false, readerr
+		return nil, readerr
+	}
+	return LintTemplateText(filePath, templateText), nil
+}
+
+// LintUserTemplateRule lints the user-defined template file on path filePath, or the remote template on URL filePath,
+// for renders with the --find= option substitution rule findRule, including the --regex, --ignore-case, --multiline,
+// and --dotall match options.  User-defined templates are not parsed as builtin templates.  Returns the diagnostics,
+// which are reported at line 1, column 1 because they apply to the whole file, and the number of findRule matches in
+// the template.  The error response is reserved for templates that cannot be read
+func LintUserTemplateRule(filePath string, findRule renderers.SubRule) ([]Diagnostic, int, error) {
+	if _, finderr := findRule.CountMatches(""); finderr != nil {
+		return []Diagnostic{{Line: 1, Column: 1, Severity: SeverityError, Rule: RuleInvalidFind, Message: fmt.Sprintf("invalid --find= value '%s'. %v", findRule.Find, finderr)}}, 0, nil
+	}
+	templateText, readerr := inkio.ReadPathOrURL(filePath)
+	if readerr != nil {
+		return nil, 0, readerr
+	}
+	matches, _ := findRule.CountMatches(templateText)
+	if matches == 0 {
+		return []Diagnostic{{Line: 1, Column: 1, Severity: SeverityWarning, Rule: RuleNoMatches, Message: fmt.Sprintf("--find= value '%s' does not match the template text", findRule.Find)}}, 0, nil
+	}
+	return nil, matches, nil
+}
+
+// LintTemplateText lints the builtin template text templateText and returns the diagnostics sorted by position.
+// templateSource is the template file path or URL that is used to resolve {{ include "path" }} template paths.  The
+// template text may begin with a front matter block that defines the template delimiters
+func LintTemplateText(templateSource string, templateText string) []Diagnostic {
+	frontMatter, bodyText, frontMatterLines, frontmattererr := renderers.SplitFrontMatter(templateText)
+	if frontmattererr != nil {
+		return []Diagnostic{{Line: 1, Column: 1, Severity: SeverityError, Rule: RuleFrontMatter, Message: frontmattererr.Error()}}
+	}
+	leftDelim, rightDelim := renderers.LeftDelim, renderers.RightDelim
+	if frontMatter != nil && len(frontMatter.LeftDelim) > 0 {
+		leftDelim, rightDelim = frontMatter.LeftDelim, frontMatter.RightDelim
+	}
+
+	diagnostics := lintDelimiters(bodyText, leftDelim, rightDelim)
+	trees, parseDiagnostics := parseTemplate(bodyText, leftDelim, rightDelim)
+	diagnostics = append(diagnostics, parseDiagnostics...)
+	if trees != nil {
+		diagnostics = append(diagnostics, lintTrees(templateSource, bodyText, trees)...)
+	}
+	for i := range diagnostics {
+		if diagnostics[i].Line > 0 {
+			diagnostics[i].Line += frontMatterLines // template text positions
+		}
+	}
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		if diagnostics[i].Line != diagnostics[j].Line {
+			return diagnostics[i].Line < diagnostics[j].Line
+		}
+		return diagnostics[i].Column < diagnostics[j].Column
+	})
+	return diagnostics
+}
+
+var parseErrorPosition = regexp.MustCompile(`^template: ink:(\d+): `)
+var undefinedFunction = regexp.MustCompile(`^function "([^"]+)" not defined$`)
+var unclosedActionStart = regexp.MustCompile(`started at ink:(\d+)`)
+
+// parseTemplate parses templateText with the delimiters leftDelim and rightDelim and the ink template functions and
+// returns the parse trees of the template and the templates that it defines.  Undefined functions are replaced with
+// stub functions and the text is parsed again so that all undefined functions are reported together with a remaining
+// syntax error.  The parse trees are nil when the template text has a syntax error
+func parseTemplate(templateText string, leftDelim string, rightDelim string) ([]*parse.Tree, []Diagnostic) {
+	funcs := renderers.TemplateFuncs()
+	unknownFuncs := make(map[string]int) // undefined function name -> line of the first call
+	for {
+		t, parseerr := template.New("ink").Delims(leftDelim, rightDelim).Funcs(funcs).Parse(templateText)
+		if parseerr == nil {
+			var trees []*parse.Tree
+			for _, definedTemplate := range t.Templates() {
+				if definedTemplate.Tree != nil {
+					trees = append(trees, definedTemplate.Tree)
+				}
+			}
+			return trees, lintUnknownFunctions(templateText, trees, unknownFuncs)
+		}
+
+		line := 0
+		message := parseerr.Error()
+		if match := parseErrorPosition.FindStringSubmatch(message); match != nil {
+			line, _ = strconv.Atoi(match[1])
+			message = strings.TrimPrefix(message, match[0])
+		}
+		if match := undefinedFunction.FindStringSubmatch(message); match != nil {
+			if _, found := unknownFuncs[match[1]]; !found {
+				unknownFuncs[match[1]] = line
+				funcs[match[1]] = func(...interface{}) interface{} { return nil }
+				continue
+			}
+		}
+
+		diagnostics := lintUnknownFunctions(templateText, nil, unknownFuncs)
+		rule := RuleParseError
+		if strings.HasPrefix(message, "unclosed action") {
+			rule = RuleUnbalancedDelimiters
+			if match := unclosedActionStart.FindStringSubmatch(message); match != nil {
+				line, _ = strconv.Atoi(match[1]) // report the line of the opening delimiter
+			}
+		}
+		// text/template parse errors do not report a column, the diagnostic is reported at the start of the line
+		return nil, append(diagnostics, Diagnostic{Line: line, Column: 1, Severity: SeverityError, Rule: rule, Message: message})
+	}
+}
+
+// lintUnknownFunctions returns an unknown-function diagnostic for each call of a function in unknownFuncs in the
+// parse trees.  Without parse trees, a diagnostic is returned for the first call line of each function
+func lintUnknownFunctions(templateText string, trees []*parse.Tree, unknownFuncs map[string]int) []Diagnostic {
+	var diagnostics []Diagnostic
+	if trees == nil {
+		lines := strings.Split(templateText, "\n")
+		for name, line := range unknownFuncs {
+			column := 1 // start of the line when the call is not found
+			if line > 0 && line <= len(lines) {
+				if index := strings.Index(lines[line-1], name); index >= 0 {
+					column = utf8.RuneCountInString(lines[line-1][:index]) + 1
+				}
+			}
+			diagnostics = append(diagnostics, Diagnostic{Line: line, Column: column, Severity: SeverityError, Rule: RuleUnknownFunction, Message: fmt.Sprintf("function %q is not defined", name)})
+		}
+		return diagnostics
+	}
+	for _, tree := range trees {
+		renderers.WalkParseTree(tree.Root, func(node parse.Node) {
+			if identifier, ok := node.(*parse.IdentifierNode); ok {
+				if _, unknown := unknownFuncs[identifier.Ident]; unknown {
+					diagnostics = append(diagnostics, newDiagnostic(templateText, int(identifier.Pos), SeverityError, RuleUnknownFunction, fmt.Sprintf("function %q is not defined", identifier.Ident)))
+				}
+			}
+		})
 	}
-	funcs := template.FuncMap{"ink": inklint}
-	_, templateerr := template.New("ink").Funcs(funcs).Parse(templateText)
-	//_, templateerr := template.New("ink").Parse(templateText)
-	if templateerr != nil {
-		return false, templateerr
+	return diagnostics
+}
+
+// lintTrees returns the unknown-field, missing-include, and no-tokens diagnostics for the template parse trees
+func lintTrees(templateSource string, templateText string, trees []*parse.Tree) []Diagnostic {
+	var diagnostics []Diagnostic
+	knownFields := map[string]bool{"Env": true}
+	replacementType := reflect.TypeOf(renderers.ReplacementStrings{})
+	for i := 0; i < replacementType.NumField(); i++ {
+		knownFields[replacementType.Field(i).Name] = true
+	}
+
+	hasTokens := false
+	for _, tree := range trees {
+		walkRootFields(tree.Root, true, func(name string, pos parse.Pos) {
+			// lowercase names are assumed to be --data keys, data keys are not known when templates are linted
+			if first, _ := utf8.DecodeRuneInString(name); unicode.IsUpper(first) && !knownFields[name] {
+				diagnostics = append(diagnostics, newDiagnostic(templateText, int(pos), SeverityWarning, RuleUnknownField, fmt.Sprintf("field .%s is not a builtin template field (.Ink, .One ... .Ten, .Env) or template data key", name)))
+			}
+		})
+
+		renderers.WalkParseTree(tree.Root, func(node parse.Node) {
+			switch n := node.(type) {
+			case *parse.TextNode, *parse.ListNode:
+			case *parse.CommandNode:
+				hasTokens = true
+				if len(n.Args) < 2 {
+					return
+				}
+				identifier, isIdentifier := n.Args[0].(*parse.IdentifierNode)
+				include, isString := n.Args[1].(*parse.StringNode)
+				if !isIdentifier || identifier.Ident != "include" || !isString {
+					return
+				}
+				// confirm that templates included with the {{ include "path" }} template function exist, included
+				// remote templates (e.g. relative include paths in remote templates) are requested
+				includePath, resolveerr := renderers.ResolveIncludePath(templateSource, include.Text)
+				if resolveerr != nil {
+					diagnostics = append(diagnostics, newDiagnostic(templateText, int(include.Pos), SeverityError, RuleMissingInclude, resolveerr.Error()))
+				} else if inkio.IsURL(includePath) {
+					if _, geterr := inkio.GetRequest(includePath); geterr != nil {
+						diagnostics = append(diagnostics, newDiagnostic(templateText, int(include.Pos), SeverityError, RuleMissingInclude, fmt.Sprintf("included template '%s' was not found. %v", include.Text, geterr)))
+					}
+				} else if exists, existserr := FileExists(includePath); !exists {
+					diagnostics = append(diagnostics, newDiagnostic(templateText, int(include.Pos), SeverityError, RuleMissingInclude, fmt.Sprintf("included template '%s' was not found. %v", include.Text, existserr)))
+				}
+			default:
+				hasTokens = true
+			}
+		})
 	}
 
-	return true, templateerr
+	if !hasTokens {
+		diagnostics = append(diagnostics, Diagnostic{Line: 1, Column: 1, Severity: SeverityWarning, Rule: RuleNoTokens, Message: "template does not contain template tokens"})
+	}
+	return diagnostics
 }
 
-// need this empty function to support valid use (and therefore linting for validity) of {{ ink }} tag in templates
-func inklint() string { return "" }
+// walkRootFields calls visit for the first field name in field chains that are evaluated on the root template data
+// (e.g. `Ink` in {{ .Ink }} and {{ $.Ink }}).  The data of the fields in {{ range }} and {{ with }} blocks is not the
+// root data, only root variable $ fields are visited in these blocks (rootDot == false)
+func walkRootFields(node parse.Node, rootDot bool, visit func(name string, pos parse.Pos)) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			walkRootFields(child, rootDot, visit)
+		}
+	case *parse.ActionNode:
+		walkRootFields(n.Pipe, rootDot, visit)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, command := range n.Cmds {
+			walkRootFields(command, rootDot, visit)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			walkRootFields(arg, rootDot, visit)
+		}
+	case *parse.ChainNode:
+		walkRootFields(n.Node, rootDot, visit)
+	case *parse.FieldNode:
+		if rootDot {
+			visit(n.Ident[0], n.Pos)
+		}
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" && len(n.Ident) > 1 {
+			visit(n.Ident[1], n.Pos)
+		}
+	case *parse.IfNode:
+		walkRootFields(n.Pipe, rootDot, visit)
+		walkRootFields(n.List, rootDot, visit)
+		walkRootFields(n.ElseList, rootDot, visit)
+	case *parse.RangeNode:
+		walkRootFields(n.Pipe, rootDot, visit)
+		walkRootFields(n.List, false, visit)
+		walkRootFields(n.ElseList, rootDot, visit)
+	case *parse.WithNode:
+		walkRootFields(n.Pipe, rootDot, visit)
+		walkRootFields(n.List, false, visit)
+		walkRootFields(n.ElseList, rootDot, visit)
+	case *parse.TemplateNode:
+		walkRootFields(n.Pipe, rootDot, visit)
+	}
+}
+
+// lintDelimiters returns the unbalanced-delimiters diagnostics for stray closing delimiters rightDelim and the
+// trailing-whitespace diagnostics for template actions in templateText.  Unclosed actions are reported by the parser
+func lintDelimiters(templateText string, leftDelim string, rightDelim string) []Diagnostic {
+	var diagnostics []Diagnostic
+	pos := 0
+	for pos < len(templateText) {
+		openIndex := strings.Index(templateText[pos:], leftDelim)
+		closeIndex := strings.Index(templateText[pos:], rightDelim)
+		if closeIndex >= 0 && (openIndex < 0 || closeIndex < openIndex) {
+			diagnostics = append(diagnostics, newDiagnostic(templateText, pos+closeIndex, SeverityWarning, RuleUnbalancedDelimiters, fmt.Sprintf("closing delimiter %s without an opening delimiter %s", rightDelim, leftDelim)))
+			pos += closeIndex + len(rightDelim)
+			continue
+		}
+		if openIndex < 0 {
+			break
+		}
+		start := pos + openIndex + len(leftDelim)
+		end := actionEnd(templateText, start, rightDelim)
+		if end < 0 {
+			break
+		}
+		if diagnostic, found := lintActionWhitespace(templateText, start, end); found {
+			diagnostics = append(diagnostics, diagnostic)
+		}
+		pos = end + len(rightDelim)
+	}
+	return diagnostics
+}
+
+// actionEnd returns the index of the closing delimiter rightDelim of the template action that starts at index start
+// in templateText, or -1 if the action is not closed.  Closing delimiters in quoted strings and comments are skipped
+func actionEnd(templateText string, start int, rightDelim string) int {
+	for i := start; i < len(templateText); i++ {
+		switch templateText[i] {
+		case '"', '\'':
+			quote := templateText[i]
+			for i++; i < len(templateText) && templateText[i] != quote && templateText[i] != '\n'; i++ {
+				if templateText[i] == '\\' {
+					i++
+				}
+			}
+		case '`':
+			closeIndex := strings.IndexByte(templateText[i+1:], '`')
+			if closeIndex < 0 {
+				return -1
+			}
+			i += closeIndex + 1
+		case '/':
+			if strings.HasPrefix(templateText[i:], "/*") {
+				closeIndex := strings.Index(templateText[i+2:], "*/")
+				if closeIndex < 0 {
+					return -1
+				}
+				i += closeIndex + 3
+			}
+		default:
+			if strings.HasPrefix(templateText[i:], rightDelim) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// lintActionWhitespace returns a trailing-whitespace diagnostic when the template action between the indices start
+// and end in templateText has more than one whitespace character before the closing delimiter (e.g. {{ ink  }} or
+// {{  ink  }}), or more whitespace before the closing delimiter than after the opening delimiter (e.g. {{ink }}).  The
+// whitespace that is required by the "{{- " and " -}}" trim markers is not counted
+func lintActionWhitespace(templateText string, start int, end int) (Diagnostic, bool) {
+	action := templateText[start:end]
+	if strings.HasPrefix(action, "- ") {
+		action = action[2:]
+	}
+	if strings.HasSuffix(action, " -") {
+		action = action[:len(action)-2]
+		end -= 2
+	}
+	trimmed := strings.TrimSpace(action)
+	if len(trimmed) == 0 || strings.HasPrefix(trimmed, "/*") {
+		return Diagnostic{}, false
+	}
+	leading := len(action) - len(strings.TrimLeft(action, " \t\r\n"))
+	trailing := len(action) - len(strings.TrimRight(action, " \t\r\n"))
+	if trailing <= 1 && trailing <= leading {
+		return Diagnostic{}, false
+	}
+	return newDiagnostic(templateText, end-trailing, SeverityWarning, RuleTrailingWhitespace, "trailing whitespace inside the template tag"), true
+}
+
+// newDiagnostic returns a Diagnostic at the byte offset offset in templateText
+func newDiagnostic(templateText string, offset int, severity string, rule string, message string) Diagnostic {
+	if offset > len(templateText) {
+		offset = len(templateText)
+	}
+	before := templateText[:offset]
+	line := strings.Count(before, "\n") + 1
+	column := utf8.RuneCountInString(before[strings.LastIndex(before, "\n")+1:]) + 1
+	return Diagnostic{Line: line, Column: column, Severity: severity, Rule: rule, Message: message}
+}
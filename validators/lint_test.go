@@ -1,8 +1,14 @@
 package validators
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/chrissimpkins/ink/renderers"
 )
 
 func TestLintTemplateSuccessValidTemplateLowercaseInk(t *testing.T) {
@@ -19,6 +25,37 @@ func TestLintTemplateSuccessValidTemplateUppercaseInk(t *testing.T) {
 	}
 }
 
+func TestLintTemplateSuccessValidTemplateEnv(t *testing.T) {
+	result, _ := LintTemplateSuccess(filepath.Join("..", "testfiles", "template_env.txt.in"))
+	if result == false {
+		t.Errorf("[FAIL] LintTemplateSuccess returned false for a valid template, expected true.")
+	}
+}
+
+func TestLintTemplateSuccessValidTemplateFuncs(t *testing.T) {
+	result, _ := LintTemplateSuccess(filepath.Join("..", "testfiles", "template_funcs.txt.in"))
+	if result == false {
+		t.Errorf("[FAIL] LintTemplateSuccess returned false for a valid template, expected true.")
+	}
+}
+
+func TestLintTemplateSuccessValidTemplateInclude(t *testing.T) {
+	result, err := LintTemplateSuccess(filepath.Join("..", "testfiles", "include", "template_include.txt.in"))
+	if result == false {
+		t.Errorf("[FAIL] LintTemplateSuccess returned false for a valid template, expected true. %v", err)
+	}
+}
+
+func TestLintTemplateSuccessMissingInclude(t *testing.T) {
+	result, err := LintTemplateSuccess(filepath.Join("..", "testfiles", "include", "template_include_missing.txt.in"))
+	if result == true {
+		t.Errorf("[FAIL] LintTemplateSuccess returned true for a template with a missing include, expected false.")
+	}
+	if err == nil {
+		t.Errorf("[FAIL] LintTemplateSuccess returned nil for error when a template with a missing include was tested, expected error message.")
+	}
+}
+
 func TestLintTemplateSuccessInvalidTemplate(t *testing.T) {
 	result, _ := LintTemplateSuccess(filepath.Join("..", "testfiles", "template_invalid.txt.in"))
 	if result == true {
@@ -35,3 +72,191 @@ func TestLintTemplateSuccessBadFilePath(t *testing.T) {
 		t.Errorf("[FAIL] LintTemplateSuccess returned nil for error when a file path to a missing file was tested, expected error message.")
 	}
 }
+
+func TestLintTemplateDiagnostics(t *testing.T) {
+	diagnostics, err := LintTemplate(filepath.Join("..", "testfiles", "template_lint.txt.in"))
+	if err != nil {
+		t.Errorf("[FAIL] LintTemplate returned an error for a readable template: %v", err)
+	}
+	expected := []string{
+		"1:10: warning: trailing whitespace inside the template tag [trailing-whitespace]",
+		"1:17: warning: field .Foo is not a builtin template field (.Ink, .One ... .Ten, .Env) or template data key [unknown-field]",
+		"2:8: error: function \"bogus\" is not defined [unknown-function]",
+		"2:18: error: function \"nope\" is not defined [unknown-function]",
+		"2:26: warning: closing delimiter }} without an opening delimiter {{ [unbalanced-delimiters]",
+		"3:12: error: included template 'nope.txt.in' was not found. stat ../testfiles/nope.txt.in: no such file or directory [missing-include]",
+	}
+	if len(diagnostics) != len(expected) {
+		t.Fatalf("[FAIL] Expected %d diagnostics, received %d: %v", len(expected), len(diagnostics), diagnostics)
+	}
+	for i, diagnostic := range diagnostics {
+		if filepath.ToSlash(diagnostic.String()) != expected[i] {
+			t.Errorf("[FAIL] Expected diagnostic '%s', received '%s'", expected[i], diagnostic.String())
+		}
+	}
+	if !HasErrors(diagnostics) {
+		t.Errorf("[FAIL] Expected HasErrors to return true for diagnostics with errors")
+	}
+}
+
+func TestLintTemplateTextRules(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string // `line:column:rule` for each diagnostic
+	}{
+		{"sha={{ ink }} {{- .Ink -}} {{ .One }} {{ .Env.SHA }} {{ .project.name }}", ""},
+		{"{{ range .items }}{{ .Name }}{{ $.Bar }}{{ end }}{{ with .Two }}{{ .Three }}{{ end }}", "1:34:unknown-field"},
+		{"{{ \"}}\" }} {{/* }} */}} {{ `}}` }}", ""},
+		{"{{  ink }}{{ ink  }}{{ink -}}", "1:17:trailing-whitespace"},
+		{"{{  ink  }}", "1:8:trailing-whitespace"},
+		{"{{ ink  }}", "1:7:trailing-whitespace"},
+		{"{{\tink\t\t}}{{- ink  -}}", "1:7:trailing-whitespace,1:18:trailing-whitespace"},
+		{"plain text", "1:1:no-tokens"},
+		{"a={{ ink }}\nb={{ .Ink\n", "2:1:unbalanced-delimiters"},
+		{"a={{ nofunc }} {{ if }}", "1:1:parse-error,1:6:unknown-function"},
+		{"{{ define \"row\" }}{{ .Bogus }}{{ end }}{{ template \"row\" . }}", "1:22:unknown-field"},
+		{"a\nb\nc\n{{ if .Ink }}", "4:1:parse-error"},
+	}
+
+	for _, testcase := range tests {
+		var received []string
+		for _, diagnostic := range LintTemplateText("test.txt.in", testcase.text) {
+			received = append(received, fmt.Sprintf("%d:%d:%s", diagnostic.Line, diagnostic.Column, diagnostic.Rule))
+		}
+		if strings.Join(received, ",") != testcase.expected {
+			t.Errorf("[FAIL] Expected diagnostics '%s' for template text '%s', received '%s'", testcase.expected, testcase.text, strings.Join(received, ","))
+		}
+	}
+}
+
+func TestLintTemplateSuccessWarningsOnly(t *testing.T) {
+	result, err := LintTemplateSuccess(filepath.Join("..", "testfiles", "template_3.txt.in"))
+	if result == false {
+		t.Errorf("[FAIL] LintTemplateSuccess returned false for a template with lint warnings only, expected true. %v", err)
+	}
+}
+
+func TestLintTemplateRemote(t *testing.T) {
+	templates := map[string]string{
+		"/templates/valid.txt.in":           `sha={{ ink }} {{ include "partials/row.txt.in" }}`,
+		"/templates/partials/row.txt.in":    `row={{ . }}`,
+		"/templates/missing_include.txt.in": `sha={{ ink }} {{ include "partials/bogus.txt.in" }}`,
+		"/templates/invalid.txt.in":         `sha={{ bogus }}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		templateText, ok := templates[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(templateText))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		templateurl string
+		success     bool
+	}{
+		{server.URL + "/templates/valid.txt.in", true},
+		{server.URL + "/templates/missing_include.txt.in", false},
+		{server.URL + "/templates/invalid.txt.in", false},
+		{server.URL + "/templates/bogus.txt.in", false},
+	}
+	for _, testcase := range tests {
+		result, err := LintTemplateSuccess(testcase.templateurl)
+		if result != testcase.success {
+			t.Errorf("[FAIL] Expected LintTemplateSuccess to return %t for %s, received %t. %v", testcase.success, testcase.templateurl, result, err)
+		}
+	}
+}
+
+func TestLintUserTemplateRule(t *testing.T) {
+	templatePath := filepath.Join("..", "testfiles", "template_regex.txt.in")
+	diagnostics, matches, err := LintUserTemplateRule(templatePath, renderers.SubRule{Find: "[0-9]", Regex: true})
+	if err != nil || len(diagnostics) > 0 || matches != 3 {
+		t.Errorf("[FAIL] Expected 3 --regex matches without diagnostics, received %d matches and %v (error %v)", matches, diagnostics, err)
+	}
+	diagnostics, _, _ = LintUserTemplateRule(templatePath, renderers.SubRule{Find: "[0-9", Regex: true})
+	if len(diagnostics) != 1 || diagnostics[0].Rule != RuleInvalidFind {
+		t.Errorf("[FAIL] Expected an invalid-find diagnostic for an invalid --regex pattern, received %v", diagnostics)
+	}
+}
+
+func TestLintUserTemplate(t *testing.T) {
+	tests := []struct {
+		templatepath string
+		findstring   string
+		matches      int
+		rule         string // expected diagnostic rule, empty for no diagnostics
+	}{
+		{filepath.Join("..", "testfiles", "template_3.txt.in"), "[[user]]", 2, ""},
+		{filepath.Join("..", "testfiles", "template_regex.txt.in"), "{{[0-9]}}", 3, ""},
+		{filepath.Join("..", "testfiles", "template_invalid.txt.in"), "{{bogus}}", 2, ""}, // not parsed as a builtin template
+		{filepath.Join("..", "testfiles", "template_1.txt.in"), "[[user]]", 0, RuleNoMatches},
+		{filepath.Join("..", "testfiles", "template_regex.txt.in"), "{{[0-9}}", 0, RuleInvalidFind},
+	}
+
+	for _, testcase := range tests {
+		diagnostics, matches, err := LintUserTemplateRule(testcase.templatepath, renderers.SubRule{Find: testcase.findstring})
+		if err != nil {
+			t.Errorf("[FAIL] LintUserTemplateRule returned an error for a readable template: %v", err)
+		}
+		if matches != testcase.matches {
+			t.Errorf("[FAIL] Expected %d matches for find string '%s', received %d", testcase.matches, testcase.findstring, matches)
+		}
+		var rules []string
+		for _, diagnostic := range diagnostics {
+			rules = append(rules, diagnostic.Rule)
+			if diagnostic.Line != 1 || diagnostic.Column != 1 {
+				t.Errorf("[FAIL] Expected the file-level diagnostic %s at 1:1, received %d:%d", diagnostic.Rule, diagnostic.Line, diagnostic.Column)
+			}
+		}
+		if strings.Join(rules, ",") != testcase.rule {
+			t.Errorf("[FAIL] Expected diagnostic rule '%s' for find string '%s', received '%s'", testcase.rule, testcase.findstring, strings.Join(rules, ","))
+		}
+	}
+
+	if _, _, err := LintUserTemplateRule(filepath.Join("..", "testfiles", "totallybogus.txt"), renderers.SubRule{Find: "[[user]]"}); err == nil {
+		t.Errorf("[FAIL] LintUserTemplateRule returned nil for error when a file path to a missing file was tested, expected error message.")
+	}
+}
+
+func TestLintTemplateDelims(t *testing.T) {
+	templatePath := filepath.Join("..", "testfiles", "include", "template_delims.txt.in")
+	renderers.LeftDelim, renderers.RightDelim = "[[", "]]"
+	diagnostics, err := LintTemplate(templatePath)
+	renderers.LeftDelim, renderers.RightDelim = "{{", "}}" // reset to default values or this interferes with other tests
+	if err != nil {
+		t.Errorf("[FAIL] LintTemplate returned an error for a readable template: %v", err)
+	}
+	if len(diagnostics) > 0 {
+		t.Errorf("[FAIL] Expected no diagnostics for a template with [[ ]] delimiters, received: %v", diagnostics)
+	}
+
+	// the default delimiters parse the {{ .Values.name }} Helm syntax as a template action
+	diagnostics, _ = LintTemplate(templatePath)
+	if len(diagnostics) != 1 || diagnostics[0].Rule != RuleUnknownField {
+		t.Errorf("[FAIL] Expected an unknown-field diagnostic for a template with [[ ]] delimiters linted with the default delimiters, received: %v", diagnostics)
+	}
+}
+
+func TestLintTemplateFrontMatter(t *testing.T) {
+	diagnostics, err := LintTemplate(filepath.Join("..", "testfiles", "template_frontmatter.txt.in"))
+	if err != nil {
+		t.Errorf("[FAIL] LintTemplate returned an error for a readable template: %v", err)
+	}
+	if len(diagnostics) > 0 {
+		t.Errorf("[FAIL] Expected no diagnostics for a template with [[ ]] front matter delimiters, received: %v", diagnostics)
+	}
+
+	// diagnostic lines are template text lines that include the front matter block
+	diagnostics = LintTemplateText("frontmatter.txt.in", "---\nstrict: true\n---\nname: {{ .name }}\nink: {{ bogus }}")
+	if len(diagnostics) != 1 || diagnostics[0].Rule != RuleUnknownFunction || diagnostics[0].Line != 5 {
+		t.Errorf("[FAIL] Expected an unknown-function diagnostic on line 5, received: %v", diagnostics)
+	}
+
+	diagnostics = LintTemplateText("frontmatter.txt.in", "---\nmode: rwx\n---\n{{ ink }}")
+	if len(diagnostics) != 1 || diagnostics[0].Rule != RuleFrontMatter || !HasErrors(diagnostics) {
+		t.Errorf("[FAIL] Expected a front-matter error diagnostic for an invalid front matter mode, received: %v", diagnostics)
+	}
+}